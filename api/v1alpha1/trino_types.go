@@ -47,6 +47,36 @@ const (
 	DefaultQueryMaxMemory = "50GB"
 )
 
+const (
+	// ConditionTypeCatalogsResolved reports whether all catalogs selected by the cluster could be resolved,
+	// e.g. a missing metastore discovery ConfigMap makes it false.
+	ConditionTypeCatalogsResolved       = "CatalogsResolved"
+	ConditionReasonCatalogsResolved     = "Resolved"
+	ConditionReasonCatalogResolveFailed = "ResolveFailed"
+)
+
+const (
+	// ConditionTypeCatalogPropertiesMigrated reports whether the legacy catalogProperties are migrated to TrinoCatalogs
+	// rendering the same catalog files, only set when the migration is enabled. When true, catalogProperties can be removed.
+	ConditionTypeCatalogPropertiesMigrated = "CatalogPropertiesMigrated"
+	ConditionReasonCatalogsMigrated        = "Migrated"
+	ConditionReasonCatalogsVerifying       = "Verifying"
+	ConditionReasonCatalogMigrationFailed  = "MigrationFailed"
+	// ConditionReasonCatalogMigrationIncomplete is set when some legacy catalogs can not be migrated,
+	// e.g. their names are not valid TrinoCatalog names, they are kept in catalogProperties.
+	ConditionReasonCatalogMigrationIncomplete = "MigrationIncomplete"
+)
+
+const (
+	CatalogPropertiesMigrationOwned      = "owned"
+	CatalogPropertiesMigrationStandalone = "standalone"
+)
+
+const (
+	CatalogManagementStatic  = "static"
+	CatalogManagementDynamic = "dynamic"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -55,8 +85,41 @@ type TrinoCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   TrinoClusterSpec `json:"spec,omitempty"`
-	Status status.Status    `json:"status,omitempty"`
+	Spec   TrinoClusterSpec   `json:"spec,omitempty"`
+	Status TrinoClusterStatus `json:"status,omitempty"`
+}
+
+// TrinoClusterStatus defines the observed state of TrinoCluster
+type TrinoClusterStatus struct {
+	status.Status `json:",inline"`
+
+	// The catalogs applied to the cluster. With static catalog management, the catalogs are applied
+	// once all pods of the cluster are rolled out with the catalog files.
+	// +kubebuilder:validation:Optional
+	Catalogs []DynamicCatalogStatus `json:"catalogs,omitempty"`
+}
+
+type DynamicCatalogStatus struct {
+	// The name of the catalog in trino, the alias when the catalog is overridden with one.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The checksum of the catalog properties applied to the cluster.
+	// +kubebuilder:validation:Optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// The generation of the TrinoCatalog the catalog is rendered from, unset for catalogs of catalogProperties.
+	// +kubebuilder:validation:Optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// Whether the catalog with the checksum is created on the coordinator, or rolled out to all pods
+	// with static catalog management.
+	// +kubebuilder:validation:Required
+	Applied bool `json:"applied"`
+
+	// The error of the last apply, if any.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -88,6 +151,8 @@ type TrinoClusterSpec struct {
 	Workers *WorkersSpec `json:"workers"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.catalogManagement) || self.catalogManagement != 'dynamic' || (has(self.dynamicCatalog) && has(self.dynamicCatalog.storeClaimName) && size(self.dynamicCatalog.storeClaimName) != 0)",message="dynamicCatalog.storeClaimName is required with dynamic catalog management"
+// +kubebuilder:validation:XValidation:rule="!has(self.catalogManagement) || self.catalogManagement != 'dynamic' || !has(self.authentication) || size(self.authentication) == 0 || (has(self.dynamicCatalog) && has(self.dynamicCatalog.credentialsSecret) && size(self.dynamicCatalog.credentialsSecret) != 0)",message="dynamicCatalog.credentialsSecret is required with dynamic catalog management when authentication is enabled"
 type ClusterConfigSpec struct {
 
 	// +kubebuilder:validation:Optional
@@ -96,10 +161,43 @@ type ClusterConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	CatalogLabelSelector *CatalogLabelSelectorSpec `json:"catalogLabelSelector,omitempty"`
 
+	// How catalogs are managed by trino.
+	//   - `static`: catalogs are rendered to catalog files, trino pods are restarted when catalogs change.
+	//   - `dynamic`: catalogs are created and dropped on the coordinator with SQL, without restarting pods.
+	//     Catalogs are stored in the catalog store, see dynamicCatalog. Nothing of the catalogs is added to the pods,
+	//     the values of the ConfigMap and Secret keys referenced by a catalog are read by the operator and sent
+	//     in the CREATE CATALOG statement, so they are stored in the catalog store. Catalogs mounting files
+	//     to the pods, e.g. a kerberos keytab, a TLS CA or a hdfs config, are not supported and reported.
+	//     A changed catalog is dropped and created again, queries running on it fail.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=static;dynamic
+	// +kubebuilder:default:="static"
+	CatalogManagement string `json:"catalogManagement,omitempty"`
+
+	// The settings of dynamic catalog management, only used when catalogManagement is `dynamic`.
 	// +kubebuilder:validation:Optional
-	// TODO: to use CatalogLabelSelector instead, as it is under construction, we will use CatalogProperties for now
+	DynamicCatalog *DynamicCatalogSpec `json:"dynamicCatalog,omitempty"`
+
+	// Overrides of the selected catalogs for this cluster, keyed by TrinoCatalog name.
+	// The TrinoCatalog is shared, overrides only change the catalog rendered for this cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=512
+	CatalogOverrides map[string]CatalogOverrideSpec `json:"catalogOverrides,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TODO: CatalogProperties is kept for compatibility, use CatalogLabelSelector with TrinoCatalog instead
 	CatalogProperties map[string]map[string]string `json:"catalogProperties,omitempty"`
 
+	// Migrate each catalogProperties entry into a TrinoCatalog with a generic connector, labeled to be
+	// selected by catalogLabelSelector. The migration is reported in the CatalogPropertiesMigrated condition.
+	// Sensitive values are moved to a Secret named `<catalog>-migrated-properties`, owned by the TrinoCatalog.
+	// Catalogs which can not be migrated, e.g. with a name that is not a valid TrinoCatalog name, are reported.
+	//   - `owned`: the TrinoCatalogs are owned by the cluster, they are deleted with it.
+	//   - `standalone`: the TrinoCatalogs are not owned, they are kept when the cluster is deleted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=owned;standalone
+	CatalogPropertiesMigration string `json:"catalogPropertiesMigration,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default:="cluster-internal"
 	ListenerClass constants.ListenerClass `json:"listenerClass,omitempty"`
@@ -111,6 +209,26 @@ type ClusterConfigSpec struct {
 	VectorAggregatorConfigMapName string `json:"vectorAggregatorConfigMapName,omitempty"`
 }
 
+type DynamicCatalogSpec struct {
+	// The name of a ReadWriteMany PersistentVolumeClaim in the cluster namespace storing the catalogs,
+	// required with dynamic catalog management. It is mounted to every trino pod, so the catalogs are shared
+	// by the coordinators and survive restarts.
+	// +kubebuilder:validation:Optional
+	StoreClaimName string `json:"storeClaimName,omitempty"`
+
+	// The credentials of the trino user issuing the catalog statements, required when the cluster has
+	// an authentication, which must accept the password, e.g. file or ldap. The user must be allowed to create
+	// and drop catalogs. It must contain the following keys:
+	//   - `username`: The trino user.
+	//   - `password`: The password of the user.
+	// It may contain `ca.crt`, the PEM encoded CA verifying the coordinator certificate when TLS is enabled.
+	// The CA of the server secret class of the cluster is used otherwise, it must have an autoTls backend.
+	// Without credentials, statements are issued as the trino-operator user with the X-Trino-User header only,
+	// which is accepted only while the coordinator has no authentication.
+	// +kubebuilder:validation:Optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
 type AuthenticationSpec struct {
 	// +kubebuilder:validation:Required
 	AuthenticationClass string    `json:"authenticationClass"`
@@ -133,6 +251,26 @@ type CatalogLabelSelectorSpec struct {
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
 	// +kubebuilder:validation:Optional
 	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// Select catalogs from the namespaces matching the selector, in addition to the namespace of the cluster.
+	// An empty selector matches all namespaces. A catalog in another namespace is only selected
+	// when the namespace of the cluster is in its `allowedNamespaces`.
+	// The ConfigMaps and Secrets referenced by such a catalog are copied to the namespace of the cluster.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+type CatalogOverrideSpec struct {
+	// The name the catalog is exposed as in trino, instead of the TrinoCatalog name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`
+	Alias string `json:"alias,omitempty"`
+
+	// Catalog properties merged into the properties of the catalog, after the config overrides of the TrinoCatalog.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=512
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches(r'^[^\\s=:]+$'))",message="property names must not contain whitespaces, '=' or ':'"
+	ConfigOverrides map[string]string `json:"configOverrides,omitempty"`
 }
 
 type TlsSpec struct {
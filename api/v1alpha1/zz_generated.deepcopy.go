@@ -23,10 +23,46 @@ package v1alpha1
 import (
 	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
 	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbfsConnectionSpec) DeepCopyInto(out *AbfsConnectionSpec) {
+	*out = *in
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(AbfsOAuthSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbfsConnectionSpec.
+func (in *AbfsConnectionSpec) DeepCopy() *AbfsConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AbfsConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbfsOAuthSpec) DeepCopyInto(out *AbfsOAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbfsOAuthSpec.
+func (in *AbfsOAuthSpec) DeepCopy() *AbfsOAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AbfsOAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 	*out = *in
@@ -82,6 +118,46 @@ func (in *BaseRoleSpec) DeepCopy() *BaseRoleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackHoleConnectorSpec) DeepCopyInto(out *BlackHoleConnectorSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackHoleConnectorSpec.
+func (in *BlackHoleConnectorSpec) DeepCopy() *BlackHoleConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackHoleConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraConnectorSpec) DeepCopyInto(out *CassandraConnectorSpec) {
+	*out = *in
+	if in.ContactPoints != nil {
+		in, out := &in.ContactPoints, &out.ContactPoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(CatalogTlsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraConnectorSpec.
+func (in *CassandraConnectorSpec) DeepCopy() *CassandraConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogLabelSelectorSpec) DeepCopyInto(out *CatalogLabelSelectorSpec) {
 	*out = *in
@@ -99,6 +175,11 @@ func (in *CatalogLabelSelectorSpec) DeepCopyInto(out *CatalogLabelSelectorSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogLabelSelectorSpec.
@@ -111,6 +192,94 @@ func (in *CatalogLabelSelectorSpec) DeepCopy() *CatalogLabelSelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogOverrideSpec) DeepCopyInto(out *CatalogOverrideSpec) {
+	*out = *in
+	if in.ConfigOverrides != nil {
+		in, out := &in.ConfigOverrides, &out.ConfigOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogOverrideSpec.
+func (in *CatalogOverrideSpec) DeepCopy() *CatalogOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogPreflightSpec) DeepCopyInto(out *CatalogPreflightSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogPreflightSpec.
+func (in *CatalogPreflightSpec) DeepCopy() *CatalogPreflightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogPreflightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogSecurityRulesSpec) DeepCopyInto(out *CatalogSecurityRulesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSecurityRulesSpec.
+func (in *CatalogSecurityRulesSpec) DeepCopy() *CatalogSecurityRulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogSecurityRulesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogSecuritySpec) DeepCopyInto(out *CatalogSecuritySpec) {
+	*out = *in
+	out.CatalogSecurityRulesSpec = in.CatalogSecurityRulesSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSecuritySpec.
+func (in *CatalogSecuritySpec) DeepCopy() *CatalogSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogTlsSpec) DeepCopyInto(out *CatalogTlsSpec) {
+	*out = *in
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(commonsv1alpha1.TLSVerificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogTlsSpec.
+func (in *CatalogTlsSpec) DeepCopy() *CatalogTlsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogTlsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfigSpec) DeepCopyInto(out *ClusterConfigSpec) {
 	*out = *in
@@ -126,6 +295,18 @@ func (in *ClusterConfigSpec) DeepCopyInto(out *ClusterConfigSpec) {
 		*out = new(CatalogLabelSelectorSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DynamicCatalog != nil {
+		in, out := &in.DynamicCatalog, &out.DynamicCatalog
+		*out = new(DynamicCatalogSpec)
+		**out = **in
+	}
+	if in.CatalogOverrides != nil {
+		in, out := &in.CatalogOverrides, &out.CatalogOverrides
+		*out = make(map[string]CatalogOverrideSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.CatalogProperties != nil {
 		in, out := &in.CatalogProperties, &out.CatalogProperties
 		*out = make(map[string]map[string]string, len(*in))
@@ -185,238 +366,736 @@ func (in *ConfigOverridesSpec) DeepCopyInto(out *ConfigOverridesSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.ExchangeManager != nil {
-		in, out := &in.ExchangeManager, &out.ExchangeManager
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.ExchangeManager != nil {
+		in, out := &in.ExchangeManager, &out.ExchangeManager
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigOverridesSpec.
+func (in *ConfigOverridesSpec) DeepCopy() *ConfigOverridesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigOverridesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
+	*out = *in
+	if in.RoleGroupConfigSpec != nil {
+		in, out := &in.RoleGroupConfigSpec, &out.RoleGroupConfigSpec
+		*out = new(commonsv1alpha1.RoleGroupConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSpec.
+func (in *ConfigSpec) DeepCopy() *ConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectorSpec) DeepCopyInto(out *ConnectorSpec) {
+	*out = *in
+	if in.Generic != nil {
+		in, out := &in.Generic, &out.Generic
+		*out = new(GenericConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hive != nil {
+		in, out := &in.Hive, &out.Hive
+		*out = new(HiveConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IceBerg != nil {
+		in, out := &in.IceBerg, &out.IceBerg
+		*out = new(IcebergConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tpcds != nil {
+		in, out := &in.Tpcds, &out.Tpcds
+		*out = new(TpcdsConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tpch != nil {
+		in, out := &in.Tpch, &out.Tpch
+		*out = new(TpchConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeltaLake != nil {
+		in, out := &in.DeltaLake, &out.DeltaLake
+		*out = new(DeltaLakeConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(SearchConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Opensearch != nil {
+		in, out := &in.Opensearch, &out.Opensearch
+		*out = new(SearchConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Postgresql != nil {
+		in, out := &in.Postgresql, &out.Postgresql
+		*out = new(JdbcConnectorSpec)
+		**out = **in
+	}
+	if in.Mysql != nil {
+		in, out := &in.Mysql, &out.Mysql
+		*out = new(JdbcConnectorSpec)
+		**out = **in
+	}
+	if in.Mariadb != nil {
+		in, out := &in.Mariadb, &out.Mariadb
+		*out = new(JdbcConnectorSpec)
+		**out = **in
+	}
+	if in.Sqlserver != nil {
+		in, out := &in.Sqlserver, &out.Sqlserver
+		*out = new(JdbcConnectorSpec)
+		**out = **in
+	}
+	if in.Hudi != nil {
+		in, out := &in.Hudi, &out.Hudi
+		*out = new(HudiConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryConnectorSpec)
+		**out = **in
+	}
+	if in.BlackHole != nil {
+		in, out := &in.BlackHole, &out.BlackHole
+		*out = new(BlackHoleConnectorSpec)
+		**out = **in
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cassandra != nil {
+		in, out := &in.Cassandra, &out.Cassandra
+		*out = new(CassandraConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorSpec.
+func (in *ConnectorSpec) DeepCopy() *ConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorsSpec) DeepCopyInto(out *CoordinatorsSpec) {
+	*out = *in
+	if in.RoleGroups != nil {
+		in, out := &in.RoleGroups, &out.RoleGroups
+		*out = make(map[string]*RoleGroupSpec, len(*in))
+		for key, val := range *in {
+			var outVal *RoleGroupSpec
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(RoleGroupSpec)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.BaseRoleSpec.DeepCopyInto(&out.BaseRoleSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorsSpec.
+func (in *CoordinatorsSpec) DeepCopy() *CoordinatorsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeltaLakeConnectorSpec) DeepCopyInto(out *DeltaLakeConnectorSpec) {
+	*out = *in
+	if in.Metastore != nil {
+		in, out := &in.Metastore, &out.Metastore
+		*out = new(MetastoreConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(s3v1alpha1.S3BucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hdfs != nil {
+		in, out := &in.Hdfs, &out.Hdfs
+		*out = new(HdfsConnectionSpec)
+		**out = **in
+	}
+	if in.Abfs != nil {
+		in, out := &in.Abfs, &out.Abfs
+		*out = new(AbfsConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(GcsConnectionSpec)
+		**out = **in
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosSpec)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(CatalogSecuritySpec)
+		**out = **in
+	}
+	if in.RegisterTableProcedureEnabled != nil {
+		in, out := &in.RegisterTableProcedureEnabled, &out.RegisterTableProcedureEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeltaLakeConnectorSpec.
+func (in *DeltaLakeConnectorSpec) DeepCopy() *DeltaLakeConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeltaLakeConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicCatalogSpec) DeepCopyInto(out *DynamicCatalogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicCatalogSpec.
+func (in *DynamicCatalogSpec) DeepCopy() *DynamicCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicCatalogStatus) DeepCopyInto(out *DynamicCatalogStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicCatalogStatus.
+func (in *DynamicCatalogStatus) DeepCopy() *DynamicCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcsConnectionSpec) DeepCopyInto(out *GcsConnectionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcsConnectionSpec.
+func (in *GcsConnectionSpec) DeepCopy() *GcsConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GcsConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericConnectorSpec) DeepCopyInto(out *GenericConnectorSpec) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = new(PropertiesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropertyValues != nil {
+		in, out := &in.PropertyValues, &out.PropertyValues
+		*out = make(map[string]GenericPropertySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericConnectorSpec.
+func (in *GenericConnectorSpec) DeepCopy() *GenericConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericPropertySpec) DeepCopyInto(out *GenericPropertySpec) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(GenericPropertyValueFromSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericPropertySpec.
+func (in *GenericPropertySpec) DeepCopy() *GenericPropertySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericPropertySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericPropertyValueFromSpec) DeepCopyInto(out *GenericPropertyValueFromSpec) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericPropertyValueFromSpec.
+func (in *GenericPropertyValueFromSpec) DeepCopy() *GenericPropertyValueFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericPropertyValueFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlueMetastoreSpec) DeepCopyInto(out *GlueMetastoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlueMetastoreSpec.
+func (in *GlueMetastoreSpec) DeepCopy() *GlueMetastoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlueMetastoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HdfsConnectionSpec) DeepCopyInto(out *HdfsConnectionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HdfsConnectionSpec.
+func (in *HdfsConnectionSpec) DeepCopy() *HdfsConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HdfsConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveCatalogSecuritySpec) DeepCopyInto(out *HiveCatalogSecuritySpec) {
+	*out = *in
+	out.CatalogSecurityRulesSpec = in.CatalogSecurityRulesSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveCatalogSecuritySpec.
+func (in *HiveCatalogSecuritySpec) DeepCopy() *HiveCatalogSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveCatalogSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConnectorSpec) DeepCopyInto(out *HiveConnectorSpec) {
+	*out = *in
+	if in.Metastore != nil {
+		in, out := &in.Metastore, &out.Metastore
+		*out = new(MetastoreConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(s3v1alpha1.S3BucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hdfs != nil {
+		in, out := &in.Hdfs, &out.Hdfs
+		*out = new(HdfsConnectionSpec)
+		**out = **in
+	}
+	if in.Abfs != nil {
+		in, out := &in.Abfs, &out.Abfs
+		*out = new(AbfsConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(GcsConnectionSpec)
+		**out = **in
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosSpec)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(HiveCatalogSecuritySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConnectorSpec.
+func (in *HiveConnectorSpec) DeepCopy() *HiveConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HudiConnectorSpec) DeepCopyInto(out *HudiConnectorSpec) {
+	*out = *in
+	if in.Metastore != nil {
+		in, out := &in.Metastore, &out.Metastore
+		*out = new(MetastoreConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(s3v1alpha1.S3BucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hdfs != nil {
+		in, out := &in.Hdfs, &out.Hdfs
+		*out = new(HdfsConnectionSpec)
+		**out = **in
+	}
+	if in.Abfs != nil {
+		in, out := &in.Abfs, &out.Abfs
+		*out = new(AbfsConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(GcsConnectionSpec)
+		**out = **in
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HudiConnectorSpec.
+func (in *HudiConnectorSpec) DeepCopy() *HudiConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HudiConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IcebergConnectorSpec) DeepCopyInto(out *IcebergConnectorSpec) {
+	*out = *in
+	if in.Metastore != nil {
+		in, out := &in.Metastore, &out.Metastore
+		*out = new(MetastoreConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(IcebergRestCatalogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jdbc != nil {
+		in, out := &in.Jdbc, &out.Jdbc
+		*out = new(IcebergJdbcCatalogSpec)
+		**out = **in
+	}
+	if in.Nessie != nil {
+		in, out := &in.Nessie, &out.Nessie
+		*out = new(IcebergNessieCatalogSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(s3v1alpha1.S3BucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hdfs != nil {
+		in, out := &in.Hdfs, &out.Hdfs
+		*out = new(HdfsConnectionSpec)
+		**out = **in
+	}
+	if in.Abfs != nil {
+		in, out := &in.Abfs, &out.Abfs
+		*out = new(AbfsConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(GcsConnectionSpec)
+		**out = **in
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosSpec)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(CatalogSecuritySpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigOverridesSpec.
-func (in *ConfigOverridesSpec) DeepCopy() *ConfigOverridesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergConnectorSpec.
+func (in *IcebergConnectorSpec) DeepCopy() *IcebergConnectorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigOverridesSpec)
+	out := new(IcebergConnectorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
+func (in *IcebergJdbcCatalogSpec) DeepCopyInto(out *IcebergJdbcCatalogSpec) {
 	*out = *in
-	if in.RoleGroupConfigSpec != nil {
-		in, out := &in.RoleGroupConfigSpec, &out.RoleGroupConfigSpec
-		*out = new(commonsv1alpha1.RoleGroupConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSpec.
-func (in *ConfigSpec) DeepCopy() *ConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergJdbcCatalogSpec.
+func (in *IcebergJdbcCatalogSpec) DeepCopy() *IcebergJdbcCatalogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigSpec)
+	out := new(IcebergJdbcCatalogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConnectorSpec) DeepCopyInto(out *ConnectorSpec) {
+func (in *IcebergNessieCatalogSpec) DeepCopyInto(out *IcebergNessieCatalogSpec) {
 	*out = *in
-	if in.Generic != nil {
-		in, out := &in.Generic, &out.Generic
-		*out = new(GenericConnectorSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Hive != nil {
-		in, out := &in.Hive, &out.Hive
-		*out = new(HiveConnectorSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.IceBerg != nil {
-		in, out := &in.IceBerg, &out.IceBerg
-		*out = new(IcebergConnectorSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tpcds != nil {
-		in, out := &in.Tpcds, &out.Tpcds
-		*out = new(TpcdsConnectorSpec)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergNessieCatalogSpec.
+func (in *IcebergNessieCatalogSpec) DeepCopy() *IcebergNessieCatalogSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Tpch != nil {
-		in, out := &in.Tpch, &out.Tpch
-		*out = new(TpchConnectorSpec)
+	out := new(IcebergNessieCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IcebergRestCatalogSpec) DeepCopyInto(out *IcebergRestCatalogSpec) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(IcebergRestOAuth2Spec)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorSpec.
-func (in *ConnectorSpec) DeepCopy() *ConnectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergRestCatalogSpec.
+func (in *IcebergRestCatalogSpec) DeepCopy() *IcebergRestCatalogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectorSpec)
+	out := new(IcebergRestCatalogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CoordinatorsSpec) DeepCopyInto(out *CoordinatorsSpec) {
+func (in *IcebergRestOAuth2Spec) DeepCopyInto(out *IcebergRestOAuth2Spec) {
 	*out = *in
-	if in.RoleGroups != nil {
-		in, out := &in.RoleGroups, &out.RoleGroups
-		*out = make(map[string]*RoleGroupSpec, len(*in))
-		for key, val := range *in {
-			var outVal *RoleGroupSpec
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = new(RoleGroupSpec)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	in.BaseRoleSpec.DeepCopyInto(&out.BaseRoleSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorsSpec.
-func (in *CoordinatorsSpec) DeepCopy() *CoordinatorsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergRestOAuth2Spec.
+func (in *IcebergRestOAuth2Spec) DeepCopy() *IcebergRestOAuth2Spec {
 	if in == nil {
 		return nil
 	}
-	out := new(CoordinatorsSpec)
+	out := new(IcebergRestOAuth2Spec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenericConnectorSpec) DeepCopyInto(out *GenericConnectorSpec) {
+func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
-	if in.Properties != nil {
-		in, out := &in.Properties, &out.Properties
-		*out = new(PropertiesSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericConnectorSpec.
-func (in *GenericConnectorSpec) DeepCopy() *GenericConnectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
+func (in *ImageSpec) DeepCopy() *ImageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GenericConnectorSpec)
+	out := new(ImageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HdfsConnectionSpec) DeepCopyInto(out *HdfsConnectionSpec) {
+func (in *JdbcConnectorSpec) DeepCopyInto(out *JdbcConnectorSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HdfsConnectionSpec.
-func (in *HdfsConnectionSpec) DeepCopy() *HdfsConnectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JdbcConnectorSpec.
+func (in *JdbcConnectorSpec) DeepCopy() *JdbcConnectorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HdfsConnectionSpec)
+	out := new(JdbcConnectorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConnectorSpec) DeepCopyInto(out *HiveConnectorSpec) {
+func (in *JvmPropertiesRoleConfigSpec) DeepCopyInto(out *JvmPropertiesRoleConfigSpec) {
 	*out = *in
-	if in.Metastore != nil {
-		in, out := &in.Metastore, &out.Metastore
-		*out = new(MetastoreConnectionSpec)
-		**out = **in
-	}
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(s3v1alpha1.S3BucketSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Hdfs != nil {
-		in, out := &in.Hdfs, &out.Hdfs
-		*out = new(HdfsConnectionSpec)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConnectorSpec.
-func (in *HiveConnectorSpec) DeepCopy() *HiveConnectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JvmPropertiesRoleConfigSpec.
+func (in *JvmPropertiesRoleConfigSpec) DeepCopy() *JvmPropertiesRoleConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConnectorSpec)
+	out := new(JvmPropertiesRoleConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IcebergConnectorSpec) DeepCopyInto(out *IcebergConnectorSpec) {
+func (in *KafkaConnectorSpec) DeepCopyInto(out *KafkaConnectorSpec) {
 	*out = *in
-	if in.Metastore != nil {
-		in, out := &in.Metastore, &out.Metastore
-		*out = new(MetastoreConnectionSpec)
-		**out = **in
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(s3v1alpha1.S3BucketSpec)
+	if in.TableDescriptions != nil {
+		in, out := &in.TableDescriptions, &out.TableDescriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(CatalogTlsSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Hdfs != nil {
-		in, out := &in.Hdfs, &out.Hdfs
-		*out = new(HdfsConnectionSpec)
+	if in.Sasl != nil {
+		in, out := &in.Sasl, &out.Sasl
+		*out = new(KafkaSaslSpec)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcebergConnectorSpec.
-func (in *IcebergConnectorSpec) DeepCopy() *IcebergConnectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConnectorSpec.
+func (in *KafkaConnectorSpec) DeepCopy() *KafkaConnectorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IcebergConnectorSpec)
+	out := new(KafkaConnectorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+func (in *KafkaSaslSpec) DeepCopyInto(out *KafkaSaslSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
-func (in *ImageSpec) DeepCopy() *ImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSaslSpec.
+func (in *KafkaSaslSpec) DeepCopy() *KafkaSaslSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSpec)
+	out := new(KafkaSaslSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JvmPropertiesRoleConfigSpec) DeepCopyInto(out *JvmPropertiesRoleConfigSpec) {
+func (in *KerberosSpec) DeepCopyInto(out *KerberosSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JvmPropertiesRoleConfigSpec.
-func (in *JvmPropertiesRoleConfigSpec) DeepCopy() *JvmPropertiesRoleConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosSpec.
+func (in *KerberosSpec) DeepCopy() *KerberosSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JvmPropertiesRoleConfigSpec)
+	out := new(KerberosSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -443,9 +1122,29 @@ func (in *LoggingSpec) DeepCopy() *LoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryConnectorSpec) DeepCopyInto(out *MemoryConnectorSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryConnectorSpec.
+func (in *MemoryConnectorSpec) DeepCopy() *MemoryConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetastoreConnectionSpec) DeepCopyInto(out *MetastoreConnectionSpec) {
 	*out = *in
+	if in.Glue != nil {
+		in, out := &in.Glue, &out.Glue
+		*out = new(GlueMetastoreSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetastoreConnectionSpec.
@@ -458,6 +1157,26 @@ func (in *MetastoreConnectionSpec) DeepCopy() *MetastoreConnectionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBConnectorSpec) DeepCopyInto(out *MongoDBConnectorSpec) {
+	*out = *in
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(CatalogTlsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBConnectorSpec.
+func (in *MongoDBConnectorSpec) DeepCopy() *MongoDBConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OidcSpec) DeepCopyInto(out *OidcSpec) {
 	*out = *in
@@ -498,6 +1217,36 @@ func (in *PropertiesSpec) DeepCopy() *PropertiesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisConnectorSpec) DeepCopyInto(out *RedisConnectorSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TableDescriptions != nil {
+		in, out := &in.TableDescriptions, &out.TableDescriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseIndex != nil {
+		in, out := &in.DatabaseIndex, &out.DatabaseIndex
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisConnectorSpec.
+func (in *RedisConnectorSpec) DeepCopy() *RedisConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleGroupSpec) DeepCopyInto(out *RoleGroupSpec) {
 	*out = *in
@@ -528,6 +1277,31 @@ func (in *RoleGroupSpec) DeepCopy() *RoleGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchConnectorSpec) DeepCopyInto(out *SearchConnectorSpec) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(CatalogTlsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchConnectorSpec.
+func (in *SearchConnectorSpec) DeepCopy() *SearchConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TlsSpec) DeepCopyInto(out *TlsSpec) {
 	*out = *in
@@ -546,6 +1320,11 @@ func (in *TlsSpec) DeepCopy() *TlsSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TpcdsConnectorSpec) DeepCopyInto(out *TpcdsConnectorSpec) {
 	*out = *in
+	if in.SplitsPerNode != nil {
+		in, out := &in.SplitsPerNode, &out.SplitsPerNode
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TpcdsConnectorSpec.
@@ -561,6 +1340,11 @@ func (in *TpcdsConnectorSpec) DeepCopy() *TpcdsConnectorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TpchConnectorSpec) DeepCopyInto(out *TpchConnectorSpec) {
 	*out = *in
+	if in.SplitsPerNode != nil {
+		in, out := &in.SplitsPerNode, &out.SplitsPerNode
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TpchConnectorSpec.
@@ -579,7 +1363,7 @@ func (in *TrinoCatalog) DeepCopyInto(out *TrinoCatalog) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrinoCatalog.
@@ -643,6 +1427,21 @@ func (in *TrinoCatalogSpec) DeepCopyInto(out *TrinoCatalogSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(CatalogTlsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(CatalogPreflightSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrinoCatalogSpec.
@@ -658,6 +1457,23 @@ func (in *TrinoCatalogSpec) DeepCopy() *TrinoCatalogSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrinoCatalogStatus) DeepCopyInto(out *TrinoCatalogStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReleasedSpec != nil {
+		in, out := &in.ReleasedSpec, &out.ReleasedSpec
+		*out = new(TrinoCatalogSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrinoCatalogStatus.
@@ -769,6 +1585,27 @@ func (in *TrinoClusterSpec) DeepCopy() *TrinoClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrinoClusterStatus) DeepCopyInto(out *TrinoClusterStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Catalogs != nil {
+		in, out := &in.Catalogs, &out.Catalogs
+		*out = make([]DynamicCatalogStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrinoClusterStatus.
+func (in *TrinoClusterStatus) DeepCopy() *TrinoClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrinoClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromConfigurationSpec) DeepCopyInto(out *ValueFromConfigurationSpec) {
 	*out = *in
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"fmt"
+
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
+)
+
+var _ Connector = &baseConnector{}
+
+// baseConnector collects the properties and pod resources of a connector.
+// Connectors embed it and fill it while resolving their spec.
+type baseConnector struct {
+	properties   *properties.Properties
+	envVars      []corev1.EnvVar
+	volumes      []corev1.Volume
+	volumeMounts []corev1.VolumeMount
+	commands     []string
+	krb5Conf     string
+	checksums    []string
+
+	searchedSecretClasses []string
+}
+
+func newBaseConnector(connectorName string) baseConnector {
+	b := newConnectorPart()
+	b.properties.Add("connector.name", connectorName)
+	return b
+}
+
+// newConnectorPart returns a baseConnector without connector name,
+// used by the parts shared between connectors, e.g. a s3 connection.
+func newConnectorPart() baseConnector {
+	return baseConnector{properties: properties.NewProperties()}
+}
+
+func (b *baseConnector) GetConfigProperties() *properties.Properties {
+	return b.properties
+}
+
+func (b *baseConnector) GetEnvVars() []corev1.EnvVar {
+	return b.envVars
+}
+
+func (b *baseConnector) GetVolumes() []corev1.Volume {
+	return b.volumes
+}
+
+func (b *baseConnector) GetVolumeMounts() []corev1.VolumeMount {
+	return b.volumeMounts
+}
+
+func (b *baseConnector) GetCommands() []string {
+	return b.commands
+}
+
+func (b *baseConnector) GetKrb5Conf() string {
+	return b.krb5Conf
+}
+
+func (b *baseConnector) GetConfigChecksums() []string {
+	return b.checksums
+}
+
+func (b *baseConnector) GetSearchedSecretClasses() []string {
+	return b.searchedSecretClasses
+}
+
+// addSecretEnvVar adds an environment variable from a Secret key,
+// and returns the reference to it in trino properties.
+func (b *baseConnector) addSecretEnvVar(envName string, secretName string, key string) string {
+	return b.addEnvVar(envName, &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			Key: key,
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: secretName,
+			},
+		},
+	})
+}
+
+// addEnvVar adds an environment variable from the source,
+// and returns the reference to it in trino properties.
+func (b *baseConnector) addEnvVar(envName string, source *corev1.EnvVarSource) string {
+	b.envVars = append(b.envVars, corev1.EnvVar{
+		Name:      envName,
+		ValueFrom: source,
+	})
+	return fmt.Sprintf("${ENV:%s}", envName)
+}
+
+// merge adds the properties and pod resources of a connector part, e.g. a s3 connection.
+func (b *baseConnector) merge(c Connector) {
+	p := c.GetConfigProperties()
+	for _, key := range p.Keys() {
+		value, _ := p.Get(key)
+		b.properties.Add(key, value)
+	}
+	b.envVars = append(b.envVars, c.GetEnvVars()...)
+	b.volumes = append(b.volumes, c.GetVolumes()...)
+	b.volumeMounts = append(b.volumeMounts, c.GetVolumeMounts()...)
+	b.commands = append(b.commands, c.GetCommands()...)
+	b.checksums = append(b.checksums, c.GetConfigChecksums()...)
+	b.searchedSecretClasses = append(b.searchedSecretClasses, c.GetSearchedSecretClasses()...)
+	if b.krb5Conf == "" {
+		b.krb5Conf = c.GetKrb5Conf()
+	}
+}
+
+// addCaCert imports the server CA of the TLS verification to the client truststore,
+// nothing is done when the server is verified with the system CAs or not verified.
+func (b *baseConnector) addCaCert(volumeName string, mountPath string, verification *commonsv1alpha1.TLSVerificationSpec) {
+	secretClass := truststore.GetCaSecretClass(verification)
+	if secretClass == "" {
+		return
+	}
+	caCert := truststore.NewCaCert(volumeName, secretClass, mountPath)
+	b.volumes = append(b.volumes, caCert.GetVolume())
+	b.volumeMounts = append(b.volumeMounts, caCert.GetVolumeMount())
+	b.commands = append(b.commands, caCert.GetCommand())
+}
+
+// addConfigMapsVolume mounts the files of the ConfigMaps to one directory, e.g. table description files.
+func (b *baseConnector) addConfigMapsVolume(volumeName string, mountPath string, configMaps []string) {
+	sources := make([]corev1.VolumeProjection, 0, len(configMaps))
+	for _, name := range configMaps {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		})
+	}
+	b.volumes = append(b.volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		},
+	})
+	b.volumeMounts = append(b.volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath})
+}
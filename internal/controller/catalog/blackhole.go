@@ -0,0 +1,20 @@
+package catalog
+
+import (
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &BlackHole{}
+
+type BlackHole struct {
+	baseConnector
+
+	Spec *trinov1alpha1.BlackHoleConnectorSpec
+}
+
+func NewBlackHole(spec *trinov1alpha1.BlackHoleConnectorSpec) *BlackHole {
+	return &BlackHole{
+		baseConnector: newBaseConnector("blackhole"),
+		Spec:          spec,
+	}
+}
@@ -0,0 +1,462 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// AnnotationConfigChecksum is the pod template annotation with the checksum of the catalog config read
+// at startup, e.g. the catalog files or the hdfs discovery ConfigMap, so the pods restart when it changes.
+const AnnotationConfigChecksum = "trino.kubedoop.dev/catalog-config-checksum"
+
+var (
+	// CatalogMountDir is the root directory of the files mounted for catalogs,
+	// each catalog has its own sub directory.
+	CatalogMountDir = path.Join(constants.KubedoopRoot, "catalog")
+
+	nonAlphanumericRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// Connector renders a catalog connector into trino catalog properties,
+// and provides the pod resources the connector depends on.
+type Connector interface {
+	GetEnvVars() []corev1.EnvVar
+	GetVolumes() []corev1.Volume
+	GetVolumeMounts() []corev1.VolumeMount
+	GetConfigProperties() *properties.Properties
+	GetCommands() []string
+	// GetKrb5Conf returns the path of the krb5.conf used by the connector, empty if kerberos is not used.
+	GetKrb5Conf() string
+	// GetConfigChecksums returns the checksums of the mounted config the connector reads at startup.
+	GetConfigChecksums() []string
+	// GetSearchedSecretClasses returns the SecretClasses whose Secrets are searched by secret-operator
+	// in the namespace of the pod, e.g. the s3 credentials.
+	GetSearchedSecretClasses() []string
+}
+
+// NewConnector resolves the connector spec of a catalog.
+// Exactly one connector is allowed by the CRD, it is checked again for catalogs created before.
+func NewConnector(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.ConnectorSpec,
+) (Connector, error) {
+	if n := countConnectors(spec); n > 1 {
+		return nil, fmt.Errorf("catalog %s has %d connectors, exactly one must be specified", catalogName, n)
+	}
+
+	switch {
+	case spec.Generic != nil:
+		return NewGeneric(ctx, client, catalogName, spec.Generic)
+	case spec.Hive != nil:
+		return NewHive(ctx, client, catalogName, spec.Hive)
+	case spec.IceBerg != nil:
+		return NewIceberg(ctx, client, catalogName, spec.IceBerg)
+	case spec.DeltaLake != nil:
+		return NewDeltaLake(ctx, client, catalogName, spec.DeltaLake)
+	case spec.Tpch != nil:
+		return NewTpch(spec.Tpch), nil
+	case spec.Tpcds != nil:
+		return NewTpcds(spec.Tpcds), nil
+	case spec.Kafka != nil:
+		return NewKafka(catalogName, spec.Kafka)
+	case spec.Elasticsearch != nil:
+		return NewSearch(catalogName, "elasticsearch", spec.Elasticsearch)
+	case spec.Opensearch != nil:
+		return NewSearch(catalogName, "opensearch", spec.Opensearch)
+	case spec.Postgresql != nil:
+		return NewJdbc(catalogName, "postgresql", spec.Postgresql)
+	case spec.Mysql != nil:
+		return NewJdbc(catalogName, "mysql", spec.Mysql)
+	case spec.Mariadb != nil:
+		return NewJdbc(catalogName, "mariadb", spec.Mariadb)
+	case spec.Sqlserver != nil:
+		return NewJdbc(catalogName, "sqlserver", spec.Sqlserver)
+	case spec.Hudi != nil:
+		return NewHudi(ctx, client, catalogName, spec.Hudi)
+	case spec.Memory != nil:
+		return NewMemory(spec.Memory), nil
+	case spec.BlackHole != nil:
+		return NewBlackHole(spec.BlackHole), nil
+	case spec.MongoDB != nil:
+		return NewMongoDB(catalogName, spec.MongoDB), nil
+	case spec.Cassandra != nil:
+		return NewCassandra(catalogName, spec.Cassandra), nil
+	case spec.Redis != nil:
+		return NewRedis(catalogName, spec.Redis), nil
+	default:
+		return nil, fmt.Errorf("catalog %s has no supported connector", catalogName)
+	}
+}
+
+// countConnectors returns the number of connectors set in the connector spec.
+func countConnectors(spec *trinov1alpha1.ConnectorSpec) int {
+	count := 0
+	value := reflect.ValueOf(spec).Elem()
+	for i := range value.NumField() {
+		if !value.Field(i).IsNil() {
+			count++
+		}
+	}
+	return count
+}
+
+var _ Connector = &Catalog{}
+
+// Catalog is a resolved TrinoCatalog.
+type Catalog struct {
+	baseConnector
+
+	Name            string
+	Namespace       string
+	ConfigOverrides map[string]string
+
+	// Generation is the generation of the TrinoCatalog the catalog is rendered from.
+	Generation int64
+
+	// Alias is the name the catalog is exposed as by the cluster, empty to use the name of the catalog.
+	Alias string
+
+	// References are the ConfigMaps and Secrets to copy to the namespace of the cluster,
+	// when the catalog is from another namespace.
+	References []Reference
+
+	// Warnings are the unknown properties of the catalog, reported in the status of the TrinoCatalog.
+	Warnings []string
+}
+
+// NewCatalog resolves the catalog in its namespace, the client is only used to read objects.
+func NewCatalog(ctx context.Context, client *client.Client, obj *trinov1alpha1.TrinoCatalog) (*Catalog, error) {
+	connector, err := NewConnector(ctx, getNamespaceClient(client, obj), obj.Name, &obj.Spec.Connector)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		baseConnector:   newConnectorPart(),
+		Name:            obj.Name,
+		Namespace:       obj.Namespace,
+		ConfigOverrides: obj.Spec.ConfigOverrides,
+		Generation:      obj.GetGeneration(),
+	}
+	c.merge(connector)
+
+	if obj.Spec.Tls != nil {
+		c.addCaCert(getVolumeName(obj.Name, "tls-ca"), getMountPath(obj.Name, "tls-ca"), obj.Spec.Tls.Verification)
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// validate validates the rendered properties of the catalog, so invalid properties never reach a pod.
+func (c *Catalog) validate() error {
+	warnings, err := ValidateProperties(c.Name, c.GetConfigProperties())
+	if err != nil {
+		return err
+	}
+	c.Warnings = warnings
+	return nil
+}
+
+// GetCatalogName returns the name the catalog is exposed as in trino.
+func (c *Catalog) GetCatalogName() string {
+	if c.Alias != "" {
+		return c.Alias
+	}
+	return c.Name
+}
+
+// MountsFiles tells whether the catalog reads files mounted or written to the pods, e.g. a keytab or a CA,
+// such a catalog can not be created on a running coordinator.
+func (c *Catalog) MountsFiles() bool {
+	return len(c.GetVolumes()) > 0 || len(c.GetCommands()) > 0
+}
+
+// applyOverride applies the override of a cluster to the catalog.
+// The config overrides are copied, as they may point to the spec of the TrinoCatalog.
+func (c *Catalog) applyOverride(override trinov1alpha1.CatalogOverrideSpec) {
+	configOverrides := make(map[string]string, len(c.ConfigOverrides)+len(override.ConfigOverrides))
+	for key, value := range c.ConfigOverrides {
+		configOverrides[key] = value
+	}
+	for key, value := range override.ConfigOverrides {
+		configOverrides[key] = value
+	}
+	c.ConfigOverrides = configOverrides
+	c.Alias = override.Alias
+}
+
+// GetConfigProperties returns the connector properties with config overrides applied.
+func (c *Catalog) GetConfigProperties() *properties.Properties {
+	p := properties.NewProperties()
+	for _, key := range c.properties.Keys() {
+		value, _ := c.properties.Get(key)
+		p.Add(key, value)
+	}
+	for key, value := range c.ConfigOverrides {
+		p.Add(key, value)
+	}
+	return p
+}
+
+// TrinoCatalogs is the set of catalogs selected by a TrinoCluster.
+type TrinoCatalogs struct {
+	Catalogs []*Catalog
+
+	// Held are the names of the selected catalogs held back until their preflight checks pass,
+	// they are rendered with their last released spec if any.
+	Held []string
+}
+
+// NewCatalogs resolves all TrinoCatalogs matched by the catalog label selector of the cluster,
+// and applies the catalog overrides of the cluster. Catalogs from other namespaces are localized to
+// the namespace of the cluster, and their short host names are qualified with their namespace.
+// Catalogs held back by their preflight checks are rendered with their last released spec,
+// or skipped when they were never released.
+func NewCatalogs(
+	ctx context.Context,
+	client *client.Client,
+	clusterConfig *trinov1alpha1.ClusterConfigSpec,
+) (*TrinoCatalogs, error) {
+	if clusterConfig == nil || clusterConfig.CatalogLabelSelector == nil {
+		return &TrinoCatalogs{}, nil
+	}
+
+	objs, err := ListTrinoCatalogs(ctx, client.Client, client.GetOwnerNamespace(), clusterConfig.CatalogLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make([]*Catalog, 0, len(objs))
+	held := make([]string, 0)
+	for i := range objs {
+		if IsHeld(&objs[i]) {
+			held = append(held, objs[i].Name)
+		}
+		released := GetReleased(&objs[i])
+		if released == nil {
+			continue
+		}
+		catalog, err := NewCatalog(ctx, client, released)
+		if err != nil {
+			return nil, err
+		}
+		if catalog.Namespace != client.GetOwnerNamespace() {
+			if err := catalog.localize(); err != nil {
+				return nil, err
+			}
+			catalog.qualifyHosts(ctx, client.Client)
+		}
+		if override, ok := clusterConfig.CatalogOverrides[catalog.Name]; ok {
+			catalog.applyOverride(override)
+			if err := catalog.validate(); err != nil {
+				return nil, err
+			}
+		}
+		catalogs = append(catalogs, catalog)
+	}
+
+	exposed := make(map[string]string, len(catalogs))
+	for _, catalog := range catalogs {
+		name := catalog.GetCatalogName()
+		if other, ok := exposed[name]; ok {
+			return nil, fmt.Errorf("catalogs %s and %s are both exposed as %s", other, catalog.Name, name)
+		}
+		exposed[name] = catalog.Name
+	}
+
+	return &TrinoCatalogs{Catalogs: catalogs, Held: held}, nil
+}
+
+// GetCatalogProperties returns the rendered properties of each catalog, keyed by the name it is exposed as.
+func (t *TrinoCatalogs) GetCatalogProperties() map[string]*properties.Properties {
+	catalogProperties := make(map[string]*properties.Properties, len(t.Catalogs))
+	for _, catalog := range t.Catalogs {
+		catalogProperties[catalog.GetCatalogName()] = catalog.GetConfigProperties()
+	}
+	return catalogProperties
+}
+
+func (t *TrinoCatalogs) GetEnvVars() []corev1.EnvVar {
+	envVars := make([]corev1.EnvVar, 0)
+	for _, catalog := range t.Catalogs {
+		envVars = append(envVars, catalog.GetEnvVars()...)
+	}
+	return envVars
+}
+
+func (t *TrinoCatalogs) GetVolumes() []corev1.Volume {
+	volumes := make([]corev1.Volume, 0)
+	for _, catalog := range t.Catalogs {
+		volumes = append(volumes, catalog.GetVolumes()...)
+	}
+	return volumes
+}
+
+func (t *TrinoCatalogs) GetVolumeMounts() []corev1.VolumeMount {
+	volumeMounts := make([]corev1.VolumeMount, 0)
+	for _, catalog := range t.Catalogs {
+		volumeMounts = append(volumeMounts, catalog.GetVolumeMounts()...)
+	}
+	return volumeMounts
+}
+
+// GetReferences returns the ConfigMaps and Secrets to copy for the catalogs from other namespaces.
+func (t *TrinoCatalogs) GetReferences() []Reference {
+	references := make([]Reference, 0)
+	for _, catalog := range t.Catalogs {
+		references = append(references, catalog.References...)
+	}
+	return references
+}
+
+func (t *TrinoCatalogs) GetCommands() []string {
+	commands := make([]string, 0)
+	for _, catalog := range t.Catalogs {
+		commands = append(commands, catalog.GetCommands()...)
+	}
+	return commands
+}
+
+// GetConfigChecksums returns the checksums of the mounted config read by the catalogs.
+func (t *TrinoCatalogs) GetConfigChecksums() []string {
+	checksums := make([]string, 0)
+	for _, catalog := range t.Catalogs {
+		checksums = append(checksums, catalog.GetConfigChecksums()...)
+	}
+	return checksums
+}
+
+// GetCatalog returns the catalog exposed as the name, nil if there is none.
+func (t *TrinoCatalogs) GetCatalog(name string) *Catalog {
+	for _, catalog := range t.Catalogs {
+		if catalog.GetCatalogName() == name {
+			return catalog
+		}
+	}
+	return nil
+}
+
+// GetGeneration returns the generation of the TrinoCatalog exposed as the name, zero if there is none.
+func (t *TrinoCatalogs) GetGeneration(name string) int64 {
+	if catalog := t.GetCatalog(name); catalog != nil {
+		return catalog.Generation
+	}
+	return 0
+}
+
+// GetKrb5Conf returns the krb5.conf of the first catalog using kerberos.
+// The jvm supports only one krb5.conf, so kerberized catalogs should share the same realm.
+func (t *TrinoCatalogs) GetKrb5Conf() string {
+	for _, catalog := range t.Catalogs {
+		if krb5Conf := catalog.GetKrb5Conf(); krb5Conf != "" {
+			return krb5Conf
+		}
+	}
+	return ""
+}
+
+// ListTrinoCatalogs lists the TrinoCatalogs selected by a cluster in the namespace, sorted by name.
+// Catalogs from the namespaces matched by the namespace selector are included when they allow the namespace,
+// a catalog of the namespace itself takes precedence over one with the same name from another namespace.
+func ListTrinoCatalogs(
+	ctx context.Context,
+	c ctrlclient.Client,
+	namespace string,
+	selector *trinov1alpha1.CatalogLabelSelectorSpec,
+) ([]trinov1alpha1.TrinoCatalog, error) {
+	labelSelector, err := GetLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := getCatalogNamespaces(ctx, c, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]trinov1alpha1.TrinoCatalog, 0)
+	names := make(map[string]bool)
+	for _, ns := range namespaces {
+		list := &trinov1alpha1.TrinoCatalogList{}
+		if err := c.List(
+			ctx,
+			list,
+			ctrlclient.InNamespace(ns),
+			ctrlclient.MatchingLabelsSelector{Selector: labelSelector},
+		); err != nil {
+			return nil, err
+		}
+		sort.Slice(list.Items, func(i, j int) bool {
+			return list.Items[i].Name < list.Items[j].Name
+		})
+		for _, item := range list.Items {
+			if names[item.Name] || !IsNamespaceAllowed(&item, namespace) {
+				continue
+			}
+			names[item.Name] = true
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+// GetLabelSelector converts the catalog label selector to a labels.Selector.
+// A nil selector matches nothing, an empty selector matches everything.
+func GetLabelSelector(selector *trinov1alpha1.CatalogLabelSelectorSpec) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Nothing(), nil
+	}
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      selector.MatchLabels,
+		MatchExpressions: selector.MatchExpressions,
+	})
+}
+
+// getEnvName returns an environment variable name scoped to the catalog,
+// e.g. CATALOG_LAKEHOUSE_JDBC_PASSWORD.
+func getEnvName(catalogName string, name string) string {
+	return strings.ToUpper(nonAlphanumericRegex.ReplaceAllString("catalog_"+catalogName+"_"+name, "_"))
+}
+
+// getVolumeName returns a volume name scoped to the catalog.
+// Volume names must be a DNS label, so long names are truncated with a hash suffix.
+func getVolumeName(catalogName string, name string) string {
+	volumeName := strings.ToLower(nonAlphanumericRegex.ReplaceAllString("catalog-"+catalogName+"-"+name, "-"))
+	if len(volumeName) <= 63 {
+		return volumeName
+	}
+	hash := sha256.Sum256([]byte(volumeName))
+	return strings.TrimRight(volumeName[:54], "-") + "-" + hex.EncodeToString(hash[:])[:8]
+}
+
+// getMountPath returns the directory where files of the catalog are mounted.
+func getMountPath(catalogName string, name string) string {
+	return path.Join(CatalogMountDir, catalogName, name)
+}
@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &DeltaLake{}
+
+type DeltaLake struct {
+	baseConnector
+
+	Spec *trinov1alpha1.DeltaLakeConnectorSpec
+}
+
+func NewDeltaLake(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.DeltaLakeConnectorSpec,
+) (*DeltaLake, error) {
+	d := &DeltaLake{
+		baseConnector: newBaseConnector("delta_lake"),
+		Spec:          spec,
+	}
+
+	metastore, err := NewMetastore(ctx, client, catalogName, spec.Metastore)
+	if err != nil {
+		return nil, err
+	}
+	if metastore.IsGlue() {
+		d.properties.Add("hive.metastore", "glue")
+	}
+	d.merge(metastore)
+
+	if spec.RegisterTableProcedureEnabled != nil {
+		d.properties.Add("delta.register-table-procedure.enabled", strconv.FormatBool(*spec.RegisterTableProcedureEnabled))
+	}
+	if spec.VacuumMinRetention != "" {
+		d.properties.Add("delta.vacuum.min-retention", spec.VacuumMinRetention)
+	}
+
+	if err := d.mergeLakeConnections(ctx, client, catalogName, lakeConnections{
+		S3:        spec.S3,
+		Hdfs:      spec.Hdfs,
+		Abfs:      spec.Abfs,
+		Gcs:       spec.Gcs,
+		Kerberos:  spec.Kerberos,
+		Metastore: !metastore.IsGlue(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if spec.Security != nil {
+		security, err := NewCatalogSecurity("delta_lake", catalogName, spec.Security.Mode, &spec.Security.CatalogSecurityRulesSpec)
+		if err != nil {
+			return nil, err
+		}
+		d.merge(security)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	// DependencyIndex is the field index of TrinoCatalogs by the objects read when they are resolved,
+	// so a change of such an object is mapped to the catalogs, and to the clusters consuming them.
+	DependencyIndex = "trino.kubedoop.dev/dependencies"
+
+	ReferenceKindS3Connection = "S3Connection"
+)
+
+// DependencyKey returns the index key of an object in the namespace of the catalog, e.g. ConfigMap/metastore.
+func DependencyKey(kind string, name string) string {
+	return kind + "/" + name
+}
+
+// GetDependencies returns the index keys of the objects read by the operator when the catalog is resolved,
+// the metastore and hdfs discovery ConfigMaps, the S3Connections and the ConfigMaps of generic properties.
+// Objects only mounted or injected as environment variables are not read.
+func GetDependencies(obj ctrlclient.Object) []string {
+	trinoCatalog, ok := obj.(*trinov1alpha1.TrinoCatalog)
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0)
+	add := func(kind string, name string) {
+		if name != "" {
+			keys = append(keys, DependencyKey(kind, name))
+		}
+	}
+	addLake := func(
+		metastore *trinov1alpha1.MetastoreConnectionSpec,
+		s3 *s3v1alpha1.S3BucketSpec,
+		hdfs *trinov1alpha1.HdfsConnectionSpec,
+	) {
+		if metastore != nil {
+			add(ReferenceKindConfigMap, metastore.ConfigMap)
+		}
+		if s3 != nil && s3.Connection != nil {
+			add(ReferenceKindS3Connection, s3.Connection.Reference)
+		}
+		if hdfs != nil {
+			add(ReferenceKindConfigMap, hdfs.ConfigMap)
+		}
+	}
+
+	connector := trinoCatalog.Spec.Connector
+	switch {
+	case connector.Hive != nil:
+		addLake(connector.Hive.Metastore, connector.Hive.S3, connector.Hive.Hdfs)
+	case connector.IceBerg != nil:
+		addLake(connector.IceBerg.Metastore, connector.IceBerg.S3, connector.IceBerg.Hdfs)
+	case connector.DeltaLake != nil:
+		addLake(connector.DeltaLake.Metastore, connector.DeltaLake.S3, connector.DeltaLake.Hdfs)
+	case connector.Hudi != nil:
+		addLake(connector.Hudi.Metastore, connector.Hudi.S3, connector.Hudi.Hdfs)
+	case connector.Generic != nil:
+		properties := connector.Generic.Properties
+		if properties != nil && properties.ValueFromConfiguration != nil && properties.ValueFromConfiguration.Type != "secret" {
+			add(ReferenceKindConfigMap, properties.ValueFromConfiguration.Name)
+		}
+	}
+	return keys
+}
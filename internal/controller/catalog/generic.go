@@ -0,0 +1,130 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &Generic{}
+
+// Generic is a connector rendered from a connector name and properties.
+// The raw properties are in java properties format, either inline or from a ConfigMap key,
+// the property values are set one by one, values from ConfigMap or Secret keys are referenced by environment variables.
+// Raw properties from a Secret key are rejected, they would be rendered in plain text.
+type Generic struct {
+	baseConnector
+
+	Spec *trinov1alpha1.GenericConnectorSpec
+}
+
+func NewGeneric(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.GenericConnectorSpec,
+) (*Generic, error) {
+	g := &Generic{
+		baseConnector: newBaseConnector(spec.Name),
+		Spec:          spec,
+	}
+
+	if spec.Properties != nil {
+		data := spec.Properties.Value
+		if spec.Properties.ValueFromConfiguration != nil {
+			var err error
+			data, err = getValueFromConfiguration(ctx, client, spec.Properties.ValueFromConfiguration)
+			if err != nil {
+				return nil, fmt.Errorf("catalog %s: %w", catalogName, err)
+			}
+		}
+
+		for key, value := range parseProperties(data) {
+			g.properties.Add(key, value)
+		}
+	}
+
+	// Sort the keys, so the environment variables are stable across reconciles.
+	keys := make([]string, 0, len(spec.PropertyValues))
+	for key := range spec.PropertyValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := g.getPropertyValue(catalogName, key, spec.PropertyValues[key])
+		if err != nil {
+			return nil, err
+		}
+		g.properties.Add(key, value)
+	}
+
+	return g, nil
+}
+
+// getPropertyValue returns the inline value of a property, or adds an environment variable
+// for a value from a ConfigMap or Secret key and returns the reference to it.
+func (g *Generic) getPropertyValue(catalogName string, key string, spec trinov1alpha1.GenericPropertySpec) (string, error) {
+	if spec.ValueFrom == nil {
+		return spec.Value, nil
+	}
+
+	envName := getEnvName(catalogName, "PROPERTY_"+key)
+	switch {
+	case spec.ValueFrom.ConfigMapKeyRef != nil && spec.ValueFrom.SecretKeyRef == nil:
+		return g.addEnvVar(envName, &corev1.EnvVarSource{ConfigMapKeyRef: spec.ValueFrom.ConfigMapKeyRef}), nil
+	case spec.ValueFrom.SecretKeyRef != nil && spec.ValueFrom.ConfigMapKeyRef == nil:
+		return g.addEnvVar(envName, &corev1.EnvVarSource{SecretKeyRef: spec.ValueFrom.SecretKeyRef}), nil
+	default:
+		return "", fmt.Errorf("catalog %s: exactly one of configMapKeyRef or secretKeyRef must be specified for property %s", catalogName, key)
+	}
+}
+
+// getValueFromConfiguration reads the value of a key from a ConfigMap in the owner namespace.
+// The `secret` type is rejected, the secret data would be rendered to the catalog properties,
+// sensitive values are set by propertyValues with valueFrom.secretKeyRef instead.
+func getValueFromConfiguration(
+	ctx context.Context,
+	client *client.Client,
+	spec *trinov1alpha1.ValueFromConfigurationSpec,
+) (string, error) {
+	if spec.Type == "secret" {
+		return "", fmt.Errorf("properties from secret %s are not supported, use propertyValues with valueFrom.secretKeyRef", spec.Name)
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := ctrlclient.ObjectKey{Namespace: client.GetOwnerNamespace(), Name: spec.Name}
+	if err := client.Client.Get(ctx, key, cm); err != nil {
+		return "", fmt.Errorf("get configmap %s: %w", spec.Name, err)
+	}
+	value, ok := cm.Data[spec.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in configmap %s", spec.Key, spec.Name)
+	}
+	return value, nil
+}
+
+// parseProperties parses java properties formatted data, comments and blank lines are skipped.
+func parseProperties(data string) map[string]string {
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return result
+}
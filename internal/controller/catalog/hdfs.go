@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// HdfsConfigFiles are the hadoop client config files in the hdfs discovery ConfigMap
+// created by hdfs-operator.
+var HdfsConfigFiles = []string{"core-site.xml", "hdfs-site.xml"}
+
+var _ Connector = &Hdfs{}
+
+// Hdfs mounts the hadoop client config of a hdfs cluster for a catalog.
+type Hdfs struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.HdfsConnectionSpec
+}
+
+func NewHdfs(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.HdfsConnectionSpec,
+) (*Hdfs, error) {
+	if spec.ConfigMap == "" {
+		return nil, fmt.Errorf("catalog %s: hdfs discovery configmap is required", catalogName)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := client.Client.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: client.GetOwnerNamespace(), Name: spec.ConfigMap},
+		cm,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("catalog %s: hdfs discovery configmap %s not found", catalogName, spec.ConfigMap)
+		}
+		return nil, err
+	}
+	for _, file := range HdfsConfigFiles {
+		if _, ok := cm.Data[file]; !ok {
+			return nil, fmt.Errorf("catalog %s: key %s not found in hdfs discovery configmap %s", catalogName, file, spec.ConfigMap)
+		}
+	}
+
+	h := &Hdfs{
+		baseConnector: newConnectorPart(),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	volumeName := getVolumeName(catalogName, "hdfs-config")
+	mountPath := getMountPath(catalogName, "hdfs-config")
+
+	items := make([]corev1.KeyToPath, 0, len(HdfsConfigFiles))
+	resources := make([]string, 0, len(HdfsConfigFiles))
+	for _, file := range HdfsConfigFiles {
+		items = append(items, corev1.KeyToPath{Key: file, Path: file})
+		resources = append(resources, path.Join(mountPath, file))
+	}
+
+	h.volumes = append(h.volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: spec.ConfigMap},
+				Items:                items,
+			},
+		},
+	})
+	h.volumeMounts = append(h.volumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+	})
+
+	// The hadoop config is read when the catalog is created, so the pods restart when it changes.
+	hash := sha256.New()
+	for _, file := range HdfsConfigFiles {
+		hash.Write([]byte(cm.Data[file]))
+	}
+	h.checksums = append(h.checksums, "configmap/"+spec.ConfigMap+"/"+hex.EncodeToString(hash.Sum(nil)))
+
+	h.properties.Add("fs.hadoop.enabled", "true")
+	h.properties.Add("hive.config.resources", strings.Join(resources, ","))
+
+	return h, nil
+}
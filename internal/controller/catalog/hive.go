@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &Hive{}
+
+type Hive struct {
+	baseConnector
+
+	Spec *trinov1alpha1.HiveConnectorSpec
+}
+
+func NewHive(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.HiveConnectorSpec,
+) (*Hive, error) {
+	h := &Hive{
+		baseConnector: newBaseConnector("hive"),
+		Spec:          spec,
+	}
+
+	metastore, err := NewMetastore(ctx, client, catalogName, spec.Metastore)
+	if err != nil {
+		return nil, err
+	}
+	if metastore.IsGlue() {
+		h.properties.Add("hive.metastore", "glue")
+	}
+	h.merge(metastore)
+
+	if err := h.mergeLakeConnections(ctx, client, catalogName, lakeConnections{
+		S3:        spec.S3,
+		Hdfs:      spec.Hdfs,
+		Abfs:      spec.Abfs,
+		Gcs:       spec.Gcs,
+		Kerberos:  spec.Kerberos,
+		Metastore: !metastore.IsGlue(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if spec.Security != nil {
+		security, err := NewCatalogSecurity("hive", catalogName, spec.Security.Mode, &spec.Security.CatalogSecurityRulesSpec)
+		if err != nil {
+			return nil, err
+		}
+		h.merge(security)
+	}
+
+	return h, nil
+}
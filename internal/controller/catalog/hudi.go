@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &Hudi{}
+
+type Hudi struct {
+	baseConnector
+
+	Spec *trinov1alpha1.HudiConnectorSpec
+}
+
+func NewHudi(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.HudiConnectorSpec,
+) (*Hudi, error) {
+	h := &Hudi{
+		baseConnector: newBaseConnector("hudi"),
+		Spec:          spec,
+	}
+
+	metastore, err := NewMetastore(ctx, client, catalogName, spec.Metastore)
+	if err != nil {
+		return nil, err
+	}
+	if metastore.IsGlue() {
+		h.properties.Add("hive.metastore", "glue")
+	}
+	h.merge(metastore)
+
+	if err := h.mergeLakeConnections(ctx, client, catalogName, lakeConnections{
+		S3:        spec.S3,
+		Hdfs:      spec.Hdfs,
+		Abfs:      spec.Abfs,
+		Gcs:       spec.Gcs,
+		Kerberos:  spec.Kerberos,
+		Metastore: !metastore.IsGlue(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
@@ -0,0 +1,172 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var jdbcDriverClasses = map[string]string{
+	"jdbc:postgresql:": "org.postgresql.Driver",
+	"jdbc:mysql:":      "com.mysql.cj.jdbc.Driver",
+	"jdbc:mariadb:":    "org.mariadb.jdbc.Driver",
+}
+
+var _ Connector = &Iceberg{}
+
+type Iceberg struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.IcebergConnectorSpec
+}
+
+func NewIceberg(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.IcebergConnectorSpec,
+) (*Iceberg, error) {
+	i := &Iceberg{
+		baseConnector: newBaseConnector("iceberg"),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	backends := 0
+	for _, set := range []bool{spec.Metastore != nil, spec.Rest != nil, spec.Jdbc != nil, spec.Nessie != nil} {
+		if set {
+			backends++
+		}
+	}
+	if backends != 1 {
+		return nil, fmt.Errorf("catalog %s: exactly one of metastore, rest, jdbc or nessie must be specified", catalogName)
+	}
+
+	switch {
+	case spec.Metastore != nil:
+		metastore, err := NewMetastore(ctx, client, catalogName, spec.Metastore)
+		if err != nil {
+			return nil, err
+		}
+		if metastore.IsGlue() {
+			i.properties.Add("iceberg.catalog.type", "glue")
+		} else {
+			i.properties.Add("iceberg.catalog.type", "hive_metastore")
+		}
+		i.merge(metastore)
+	case spec.Rest != nil:
+		i.addRestCatalog(spec.Rest)
+	case spec.Jdbc != nil:
+		if err := i.addJdbcCatalog(spec.Jdbc); err != nil {
+			return nil, err
+		}
+	case spec.Nessie != nil:
+		i.addNessieCatalog(spec.Nessie)
+	}
+
+	if err := i.mergeLakeConnections(ctx, client, catalogName, lakeConnections{
+		S3:        spec.S3,
+		Hdfs:      spec.Hdfs,
+		Abfs:      spec.Abfs,
+		Gcs:       spec.Gcs,
+		Kerberos:  spec.Kerberos,
+		Metastore: spec.Metastore != nil && spec.Metastore.Glue == nil,
+	}); err != nil {
+		return nil, err
+	}
+
+	if spec.Security != nil {
+		security, err := NewCatalogSecurity("iceberg", catalogName, spec.Security.Mode, &spec.Security.CatalogSecurityRulesSpec)
+		if err != nil {
+			return nil, err
+		}
+		i.merge(security)
+	}
+
+	return i, nil
+}
+
+func (i *Iceberg) addRestCatalog(spec *trinov1alpha1.IcebergRestCatalogSpec) {
+	i.properties.Add("iceberg.catalog.type", "rest")
+	i.properties.Add("iceberg.rest-catalog.uri", spec.Uri)
+	if spec.Warehouse != "" {
+		i.properties.Add("iceberg.rest-catalog.warehouse", spec.Warehouse)
+	}
+
+	if spec.OAuth2 != nil {
+		clientId := i.addSecretEnvVar(getEnvName(i.CatalogName, "OAUTH2_CLIENT_ID"), spec.OAuth2.ClientCredentialsSecret, "CLIENT_ID")
+		clientSecret := i.addSecretEnvVar(
+			getEnvName(i.CatalogName, "OAUTH2_CLIENT_SECRET"),
+			spec.OAuth2.ClientCredentialsSecret,
+			"CLIENT_SECRET",
+		)
+		i.properties.Add("iceberg.rest-catalog.security", "OAUTH2")
+		i.properties.Add("iceberg.rest-catalog.oauth2.credential", clientId+":"+clientSecret)
+		if spec.OAuth2.ServerUri != "" {
+			i.properties.Add("iceberg.rest-catalog.oauth2.server-uri", spec.OAuth2.ServerUri)
+		}
+		if spec.OAuth2.Scope != "" {
+			i.properties.Add("iceberg.rest-catalog.oauth2.scope", spec.OAuth2.Scope)
+		}
+	}
+}
+
+func (i *Iceberg) addJdbcCatalog(spec *trinov1alpha1.IcebergJdbcCatalogSpec) error {
+	driverClass := spec.DriverClass
+	if driverClass == "" {
+		for prefix, class := range jdbcDriverClasses {
+			if strings.HasPrefix(spec.ConnectionUrl, prefix) {
+				driverClass = class
+				break
+			}
+		}
+	}
+	if driverClass == "" {
+		return fmt.Errorf("catalog %s: driverClass is required for jdbc connection url %s", i.CatalogName, spec.ConnectionUrl)
+	}
+
+	catalogName := spec.CatalogName
+	if catalogName == "" {
+		catalogName = i.CatalogName
+	}
+
+	i.properties.Add("iceberg.catalog.type", "jdbc")
+	i.properties.Add("iceberg.jdbc-catalog.driver-class", driverClass)
+	i.properties.Add("iceberg.jdbc-catalog.connection-url", spec.ConnectionUrl)
+	i.properties.Add(
+		"iceberg.jdbc-catalog.connection-user",
+		i.addSecretEnvVar(getEnvName(i.CatalogName, "JDBC_USERNAME"), spec.CredentialsSecret, "username"),
+	)
+	i.properties.Add(
+		"iceberg.jdbc-catalog.connection-password",
+		i.addSecretEnvVar(getEnvName(i.CatalogName, "JDBC_PASSWORD"), spec.CredentialsSecret, "password"),
+	)
+	i.properties.Add("iceberg.jdbc-catalog.catalog-name", catalogName)
+	i.properties.Add("iceberg.jdbc-catalog.default-warehouse-dir", spec.DefaultWarehouseDir)
+	return nil
+}
+
+func (i *Iceberg) addNessieCatalog(spec *trinov1alpha1.IcebergNessieCatalogSpec) {
+	ref := spec.Ref
+	if ref == "" {
+		ref = "main"
+	}
+
+	i.properties.Add("iceberg.catalog.type", "nessie")
+	i.properties.Add("iceberg.nessie-catalog.uri", spec.Uri)
+	i.properties.Add("iceberg.nessie-catalog.ref", ref)
+	i.properties.Add("iceberg.nessie-catalog.default-warehouse-dir", spec.DefaultWarehouseDir)
+
+	if spec.TokenSecret != "" {
+		i.properties.Add("iceberg.nessie-catalog.authentication.type", "BEARER")
+		i.properties.Add(
+			"iceberg.nessie-catalog.authentication.token",
+			i.addSecretEnvVar(getEnvName(i.CatalogName, "NESSIE_TOKEN"), spec.TokenSecret, "TOKEN"),
+		)
+	}
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	JdbcTlsModeDisabled   = "disabled"
+	JdbcTlsModeRequired   = "required"
+	JdbcTlsModeVerifyCa   = "verifyCa"
+	JdbcTlsModeVerifyFull = "verifyFull"
+)
+
+// jdbcDatabase describes how the connection url of a database is rendered.
+type jdbcDatabase struct {
+	defaultPort      int32
+	databaseRequired bool
+	// url returns the connection url of the address, database and TLS mode.
+	url func(address string, database string, tlsMode string) string
+}
+
+var jdbcDatabases = map[string]jdbcDatabase{
+	"postgresql": {
+		defaultPort:      5432,
+		databaseRequired: true,
+		url: func(address string, database string, tlsMode string) string {
+			params := map[string]string{
+				JdbcTlsModeDisabled:   "sslmode=disable",
+				JdbcTlsModeRequired:   "sslmode=require",
+				JdbcTlsModeVerifyCa:   "sslmode=verify-ca&sslfactory=org.postgresql.ssl.DefaultJavaSSLFactory",
+				JdbcTlsModeVerifyFull: "sslmode=verify-full&sslfactory=org.postgresql.ssl.DefaultJavaSSLFactory",
+			}
+			return joinUrl("jdbc:postgresql://"+address+"/"+database, "?", params[tlsMode])
+		},
+	},
+	"mysql": {
+		defaultPort: 3306,
+		url: func(address string, _ string, tlsMode string) string {
+			params := map[string]string{
+				JdbcTlsModeDisabled:   "sslMode=DISABLED",
+				JdbcTlsModeRequired:   "sslMode=REQUIRED",
+				JdbcTlsModeVerifyCa:   "sslMode=VERIFY_CA",
+				JdbcTlsModeVerifyFull: "sslMode=VERIFY_IDENTITY",
+			}
+			return joinUrl("jdbc:mysql://"+address, "?", params[tlsMode])
+		},
+	},
+	"mariadb": {
+		defaultPort: 3306,
+		url: func(address string, _ string, tlsMode string) string {
+			params := map[string]string{
+				JdbcTlsModeDisabled:   "sslMode=disable",
+				JdbcTlsModeRequired:   "sslMode=trust",
+				JdbcTlsModeVerifyCa:   "sslMode=verify-ca",
+				JdbcTlsModeVerifyFull: "sslMode=verify-full",
+			}
+			return joinUrl("jdbc:mariadb://"+address, "?", params[tlsMode])
+		},
+	},
+	"sqlserver": {
+		defaultPort:      1433,
+		databaseRequired: true,
+		url: func(address string, database string, tlsMode string) string {
+			params := map[string]string{
+				JdbcTlsModeDisabled:   "encrypt=false",
+				JdbcTlsModeRequired:   "encrypt=true;trustServerCertificate=true",
+				JdbcTlsModeVerifyCa:   "encrypt=true;trustServerCertificate=false",
+				JdbcTlsModeVerifyFull: "encrypt=true;trustServerCertificate=false",
+			}
+			return joinUrl("jdbc:sqlserver://"+address+";databaseName="+database, ";", params[tlsMode])
+		},
+	},
+}
+
+var _ Connector = &Jdbc{}
+
+// Jdbc is a catalog of a postgresql, mysql, mariadb or sqlserver database,
+// the connector name is the name of the database.
+type Jdbc struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.JdbcConnectorSpec
+}
+
+func NewJdbc(catalogName string, connectorName string, spec *trinov1alpha1.JdbcConnectorSpec) (*Jdbc, error) {
+	database, ok := jdbcDatabases[connectorName]
+	if !ok {
+		return nil, fmt.Errorf("catalog %s: unsupported jdbc connector %s", catalogName, connectorName)
+	}
+	if database.databaseRequired && spec.Database == "" {
+		return nil, fmt.Errorf("catalog %s: database is required for %s connector", catalogName, connectorName)
+	}
+
+	j := &Jdbc{
+		baseConnector: newBaseConnector(connectorName),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	port := spec.Port
+	if port == 0 {
+		port = database.defaultPort
+	}
+	address := net.JoinHostPort(spec.Host, strconv.Itoa(int(port)))
+
+	j.properties.Add("connection-url", database.url(address, spec.Database, spec.TlsMode))
+	j.properties.Add(
+		"connection-user",
+		j.addSecretEnvVar(getEnvName(catalogName, "JDBC_USERNAME"), spec.CredentialsSecret, "username"),
+	)
+	j.properties.Add(
+		"connection-password",
+		j.addSecretEnvVar(getEnvName(catalogName, "JDBC_PASSWORD"), spec.CredentialsSecret, "password"),
+	)
+
+	return j, nil
+}
+
+// joinUrl appends the params to the url with the separator, the url is returned as is without params.
+func joinUrl(url string, separator string, params string) string {
+	if params == "" {
+		return url
+	}
+	return strings.Join([]string{url, params}, separator)
+}
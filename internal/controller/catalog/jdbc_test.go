@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestJdbc(t *testing.T) {
+	tests := []struct {
+		name          string
+		connectorName string
+		spec          *trinov1alpha1.JdbcConnectorSpec
+		wantUrl       string
+		wantErr       string
+	}{
+		{
+			name:          "postgresql",
+			connectorName: "postgresql",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "postgresql", Database: "sales"},
+			wantUrl:       "jdbc:postgresql://postgresql:5432/sales",
+		},
+		{
+			name:          "postgresql verify full",
+			connectorName: "postgresql",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "postgresql", Port: 5433, Database: "sales", TlsMode: JdbcTlsModeVerifyFull},
+			wantUrl:       "jdbc:postgresql://postgresql:5433/sales?sslmode=verify-full&sslfactory=org.postgresql.ssl.DefaultJavaSSLFactory",
+		},
+		{
+			name:          "mysql verify ca",
+			connectorName: "mysql",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "mysql", Database: "ignored", TlsMode: JdbcTlsModeVerifyCa},
+			wantUrl:       "jdbc:mysql://mysql:3306?sslMode=VERIFY_CA",
+		},
+		{
+			name:          "mariadb required",
+			connectorName: "mariadb",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "mariadb", TlsMode: JdbcTlsModeRequired},
+			wantUrl:       "jdbc:mariadb://mariadb:3306?sslMode=trust",
+		},
+		{
+			name:          "sqlserver disabled",
+			connectorName: "sqlserver",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "sqlserver", Database: "sales", TlsMode: JdbcTlsModeDisabled},
+			wantUrl:       "jdbc:sqlserver://sqlserver:1433;databaseName=sales;encrypt=false",
+		},
+		{
+			name:          "ipv6 host",
+			connectorName: "postgresql",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "fd00::1", Database: "sales"},
+			wantUrl:       "jdbc:postgresql://[fd00::1]:5432/sales",
+		},
+		{
+			name:          "sqlserver without database",
+			connectorName: "sqlserver",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "sqlserver"},
+			wantErr:       "catalog sales: database is required for sqlserver connector",
+		},
+		{
+			name:          "unsupported database",
+			connectorName: "oracle",
+			spec:          &trinov1alpha1.JdbcConnectorSpec{Host: "oracle"},
+			wantErr:       "catalog sales: unsupported jdbc connector oracle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tt.spec.CredentialsSecret = "db-credentials"
+			jdbc, err := NewJdbc("sales", tt.connectorName, tt.spec)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			rendered, err := jdbc.GetConfigProperties().Marshal()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(`connection-password=${ENV:CATALOG_SALES_JDBC_PASSWORD}
+connection-url=` + tt.wantUrl + `
+connection-user=${ENV:CATALOG_SALES_JDBC_USERNAME}
+connector.name=` + tt.connectorName + `
+`))
+
+			envKeys := make(map[string]string)
+			for _, env := range jdbc.GetEnvVars() {
+				g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal("db-credentials"))
+				envKeys[env.Name] = env.ValueFrom.SecretKeyRef.Key
+			}
+			g.Expect(envKeys).To(Equal(map[string]string{
+				"CATALOG_SALES_JDBC_USERNAME": "username",
+				"CATALOG_SALES_JDBC_PASSWORD": "password",
+			}))
+
+			warnings, err := ValidateProperties("sales", jdbc.GetConfigProperties())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
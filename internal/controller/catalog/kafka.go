@@ -0,0 +1,123 @@
+package catalog
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	"github.com/zncdatadev/operator-go/pkg/util"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
+)
+
+const (
+	DefaultKafkaSaslMechanism = "PLAIN"
+
+	// kafkaEnvVarConfigProvider resolves `${env:NAME}` in the kafka client config, available since kafka 3.5.
+	kafkaEnvVarConfigProvider = "org.apache.kafka.common.config.provider.EnvVarConfigProvider"
+)
+
+var kafkaSaslLoginModules = map[string]string{
+	"PLAIN":         "org.apache.kafka.common.security.plain.PlainLoginModule",
+	"SCRAM-SHA-256": "org.apache.kafka.common.security.scram.ScramLoginModule",
+	"SCRAM-SHA-512": "org.apache.kafka.common.security.scram.ScramLoginModule",
+}
+
+var _ Connector = &Kafka{}
+
+type Kafka struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.KafkaConnectorSpec
+}
+
+func NewKafka(catalogName string, spec *trinov1alpha1.KafkaConnectorSpec) (*Kafka, error) {
+	if len(spec.BootstrapServers) == 0 {
+		return nil, fmt.Errorf("catalog %s: kafka bootstrap servers are required", catalogName)
+	}
+	if spec.SchemaRegistryUrl != "" && len(spec.TableDescriptions) > 0 {
+		return nil, fmt.Errorf("catalog %s: only one of kafka table descriptions or schema registry url can be specified", catalogName)
+	}
+
+	k := &Kafka{
+		baseConnector: newBaseConnector("kafka"),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	k.properties.Add("kafka.nodes", strings.Join(spec.BootstrapServers, ","))
+	if spec.DefaultSchema != "" {
+		k.properties.Add("kafka.default-schema", spec.DefaultSchema)
+	}
+
+	if spec.SchemaRegistryUrl != "" {
+		k.properties.Add("kafka.table-description-supplier", "CONFLUENT")
+		k.properties.Add("kafka.confluent-schema-registry-url", spec.SchemaRegistryUrl)
+	} else if len(spec.TableDescriptions) > 0 {
+		k.addTableDescriptions(spec.TableDescriptions)
+	}
+
+	securityProtocol := "PLAINTEXT"
+	if spec.Tls != nil {
+		securityProtocol = "SSL"
+		k.addCaCert(getVolumeName(catalogName, "kafka-tls-ca"), getMountPath(catalogName, "kafka-tls-ca"), spec.Tls.Verification)
+		k.properties.Add("kafka.ssl.truststore.location", truststore.Path)
+		k.properties.Add("kafka.ssl.truststore.password", truststore.Passphrase)
+		k.properties.Add("kafka.ssl.truststore.type", "PKCS12")
+	}
+	if spec.Sasl != nil {
+		securityProtocol = "SASL_" + securityProtocol
+		if err := k.addSasl(spec.Sasl); err != nil {
+			return nil, err
+		}
+	}
+	k.properties.Add("kafka.security-protocol", securityProtocol)
+
+	return k, nil
+}
+
+// addTableDescriptions mounts the json files of the table description ConfigMaps to one directory.
+func (k *Kafka) addTableDescriptions(configMaps []string) {
+	volumeName := getVolumeName(k.CatalogName, "kafka-table-descriptions")
+	mountPath := getMountPath(k.CatalogName, "kafka-table-descriptions")
+
+	k.addConfigMapsVolume(volumeName, mountPath, configMaps)
+	k.properties.Add("kafka.table-description-supplier", "FILE")
+	k.properties.Add("kafka.table-description-dir", mountPath)
+}
+
+// addSasl writes the sasl settings of the kafka client to a config resource file when the container starts.
+// The kafka client config does not support trino environment variable substitution, so the credentials
+// are referenced by the environment variable config provider of the kafka client, they are never written to the file.
+func (k *Kafka) addSasl(spec *trinov1alpha1.KafkaSaslSpec) error {
+	mechanism := spec.Mechanism
+	if mechanism == "" {
+		mechanism = DefaultKafkaSaslMechanism
+	}
+	loginModule, ok := kafkaSaslLoginModules[mechanism]
+	if !ok {
+		return fmt.Errorf("catalog %s: unsupported kafka sasl mechanism %s", k.CatalogName, mechanism)
+	}
+
+	usernameEnvName := getEnvName(k.CatalogName, "KAFKA_USERNAME")
+	passwordEnvName := getEnvName(k.CatalogName, "KAFKA_PASSWORD")
+	k.addSecretEnvVar(usernameEnvName, spec.CredentialsSecret, "username")
+	k.addSecretEnvVar(passwordEnvName, spec.CredentialsSecret, "password")
+
+	configFile := path.Join(constants.KubedoopConfigDir, "kafka", k.CatalogName+".properties")
+	cmd := `
+mkdir -p ` + path.Dir(configFile) + `
+cat > ` + configFile + ` <<'EOF'
+config.providers=env
+config.providers.env.class=` + kafkaEnvVarConfigProvider + `
+sasl.mechanism=` + mechanism + `
+sasl.jaas.config=` + loginModule + ` required username="${env:` + usernameEnvName + `}" password="${env:` + passwordEnvName + `}";
+EOF
+`
+	k.commands = append(k.commands, util.IndentTab4Spaces(cmd))
+	k.properties.Add("kafka.config.resources", configFile)
+	return nil
+}
@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestKafka(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         *trinov1alpha1.KafkaConnectorSpec
+		want         string
+		wantEnvNames []string
+		wantVolumes  int
+		wantCommand  string
+		wantErr      string
+	}{
+		{
+			name: "table descriptions",
+			spec: &trinov1alpha1.KafkaConnectorSpec{
+				BootstrapServers:  []string{"kafka-0.kafka:9092", "kafka-1.kafka:9092"},
+				DefaultSchema:     "events",
+				TableDescriptions: []string{"orders-table", "clicks-table"},
+			},
+			want: `connector.name=kafka
+kafka.default-schema=events
+kafka.nodes=kafka-0.kafka:9092,kafka-1.kafka:9092
+kafka.security-protocol=PLAINTEXT
+kafka.table-description-dir=/kubedoop/catalog/events/kafka-table-descriptions
+kafka.table-description-supplier=FILE
+`,
+			wantVolumes: 1,
+		},
+		{
+			name: "schema registry",
+			spec: &trinov1alpha1.KafkaConnectorSpec{
+				BootstrapServers:  []string{"kafka:9092"},
+				SchemaRegistryUrl: "http://schema-registry:8081",
+			},
+			want: `connector.name=kafka
+kafka.confluent-schema-registry-url=http://schema-registry:8081
+kafka.nodes=kafka:9092
+kafka.security-protocol=PLAINTEXT
+kafka.table-description-supplier=CONFLUENT
+`,
+		},
+		{
+			name: "sasl over tls",
+			spec: &trinov1alpha1.KafkaConnectorSpec{
+				BootstrapServers: []string{"kafka:9093"},
+				Tls: &trinov1alpha1.CatalogTlsSpec{
+					Verification: &commonsv1alpha1.TLSVerificationSpec{
+						Server: &commonsv1alpha1.ServerVerification{
+							CACert: &commonsv1alpha1.CACert{SecretClass: "tls"},
+						},
+					},
+				},
+				Sasl: &trinov1alpha1.KafkaSaslSpec{
+					Mechanism:         "SCRAM-SHA-512",
+					CredentialsSecret: "kafka-credentials",
+				},
+			},
+			want: `connector.name=kafka
+kafka.config.resources=/kubedoop/config/kafka/events.properties
+kafka.nodes=kafka:9093
+kafka.security-protocol=SASL_SSL
+kafka.ssl.truststore.location=/kubedoop/tls/client/truststore.p12
+kafka.ssl.truststore.password=changeit
+kafka.ssl.truststore.type=PKCS12
+`,
+			wantEnvNames: []string{"CATALOG_EVENTS_KAFKA_USERNAME", "CATALOG_EVENTS_KAFKA_PASSWORD"},
+			wantVolumes:  1,
+			wantCommand: `sasl.jaas.config=org.apache.kafka.common.security.scram.ScramLoginModule required ` +
+				`username="${env:CATALOG_EVENTS_KAFKA_USERNAME}" password="${env:CATALOG_EVENTS_KAFKA_PASSWORD}";`,
+		},
+		{
+			name: "unsupported sasl mechanism",
+			spec: &trinov1alpha1.KafkaConnectorSpec{
+				BootstrapServers: []string{"kafka:9092"},
+				Sasl: &trinov1alpha1.KafkaSaslSpec{
+					Mechanism:         "GSSAPI",
+					CredentialsSecret: "kafka-credentials",
+				},
+			},
+			wantErr: "catalog events: unsupported kafka sasl mechanism GSSAPI",
+		},
+		{
+			name: "table descriptions and schema registry",
+			spec: &trinov1alpha1.KafkaConnectorSpec{
+				BootstrapServers:  []string{"kafka:9092"},
+				TableDescriptions: []string{"orders-table"},
+				SchemaRegistryUrl: "http://schema-registry:8081",
+			},
+			wantErr: "catalog events: only one of kafka table descriptions or schema registry url can be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kafka, err := NewKafka("events", tt.spec)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			rendered, err := kafka.GetConfigProperties().Marshal()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(tt.want))
+
+			envNames := make([]string, 0)
+			for _, env := range kafka.GetEnvVars() {
+				g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal("kafka-credentials"))
+				envNames = append(envNames, env.Name)
+			}
+			g.Expect(envNames).To(ConsistOf(tt.wantEnvNames))
+			g.Expect(kafka.GetVolumes()).To(HaveLen(tt.wantVolumes))
+
+			// The credentials are only referenced by the config resource file, never written to it.
+			if tt.wantCommand != "" {
+				g.Expect(kafka.GetCommands()).To(ContainElement(ContainSubstring(tt.wantCommand)))
+			}
+
+			warnings, err := ValidateProperties("events", kafka.GetConfigProperties())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
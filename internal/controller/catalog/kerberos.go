@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/builder"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	"github.com/zncdatadev/operator-go/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	DefaultKerberosPrincipal          = "trino/_HOST"
+	DefaultKerberosMetastorePrincipal = "hive/_HOST"
+)
+
+var _ Connector = &Kerberos{}
+
+// Kerberos provisions a keytab and krb5.conf by secret-operator, and renders the kerberos
+// authentication of the hive metastore and hdfs used by a catalog.
+type Kerberos struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.KerberosSpec
+}
+
+// NewKerberos returns the kerberos part of a catalog, metastore and hdfs tell which
+// of the hive metastore and hdfs authentication are rendered.
+func NewKerberos(catalogName string, spec *trinov1alpha1.KerberosSpec, metastore bool, hdfs bool) (*Kerberos, error) {
+	if spec.SecretClass == "" {
+		return nil, fmt.Errorf("catalog %s: kerberos secret class is required", catalogName)
+	}
+	if !metastore && !hdfs {
+		return nil, fmt.Errorf("catalog %s: kerberos requires a hive metastore or hdfs connection", catalogName)
+	}
+
+	k := &Kerberos{
+		baseConnector: newConnectorPart(),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	principal := spec.Principal
+	if principal == "" {
+		principal = DefaultKerberosPrincipal
+	}
+	metastorePrincipal := spec.MetastorePrincipal
+	if metastorePrincipal == "" {
+		metastorePrincipal = DefaultKerberosMetastorePrincipal
+	}
+
+	volumeName := getVolumeName(catalogName, "kerberos")
+	mountPath := getMountPath(catalogName, "kerberos")
+	keytab := path.Join(mountPath, "keytab")
+	k.krb5Conf = path.Join(mountPath, "krb5.conf")
+
+	volume := builder.NewSecretOperatorVolume(volumeName, spec.SecretClass)
+	volume.SetScope(&builder.SecretVolumeScope{Pod: true, Node: true})
+	volume.SetFormatName(constants.Kerberos)
+	volume.SetKerberosServiceNames(strings.SplitN(principal, "/", 2)[0])
+	k.volumes = append(k.volumes, *volume.Builde())
+	k.volumeMounts = append(k.volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath})
+
+	realmEnvName := getEnvName(catalogName, "KERBEROS_REALM")
+	cmd := `
+export ` + realmEnvName + `=$(grep -oP 'default_realm = \K.*' ` + k.krb5Conf + `)
+`
+	k.commands = append(k.commands, util.IndentTab4Spaces(cmd))
+
+	principal = withRealm(principal, realmEnvName)
+	if metastore {
+		k.properties.Add("hive.metastore.authentication.type", "KERBEROS")
+		k.properties.Add("hive.metastore.service.principal", withRealm(metastorePrincipal, realmEnvName))
+		k.properties.Add("hive.metastore.client.principal", principal)
+		k.properties.Add("hive.metastore.client.keytab", keytab)
+	}
+	if hdfs {
+		k.properties.Add("hive.hdfs.authentication.type", "KERBEROS")
+		k.properties.Add("hive.hdfs.trino.principal", principal)
+		k.properties.Add("hive.hdfs.trino.keytab", keytab)
+	}
+
+	return k, nil
+}
+
+// withRealm appends the realm read from krb5.conf to a principal without realm.
+func withRealm(principal string, realmEnvName string) string {
+	if strings.Contains(principal, "@") {
+		return principal
+	}
+	return fmt.Sprintf("%s@${ENV:%s}", principal, realmEnvName)
+}
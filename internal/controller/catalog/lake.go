@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"context"
+
+	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
+	"github.com/zncdatadev/operator-go/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// lakeConnections are the storage and kerberos connections shared by the hive, iceberg, delta lake and hudi connectors.
+type lakeConnections struct {
+	S3       *s3v1alpha1.S3BucketSpec
+	Hdfs     *trinov1alpha1.HdfsConnectionSpec
+	Abfs     *trinov1alpha1.AbfsConnectionSpec
+	Gcs      *trinov1alpha1.GcsConnectionSpec
+	Kerberos *trinov1alpha1.KerberosSpec
+	// Metastore tells whether the catalog uses a thrift hive metastore, which is authenticated with kerberos.
+	Metastore bool
+}
+
+// mergeLakeConnections resolves the lake connections and merges them to the connector.
+func (b *baseConnector) mergeLakeConnections(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	connections lakeConnections,
+) error {
+	if connections.S3 != nil {
+		s3, err := NewS3(ctx, client, catalogName, connections.S3)
+		if err != nil {
+			return err
+		}
+		b.merge(s3)
+	}
+
+	if connections.Hdfs != nil {
+		hdfs, err := NewHdfs(ctx, client, catalogName, connections.Hdfs)
+		if err != nil {
+			return err
+		}
+		b.merge(hdfs)
+	}
+
+	if connections.Abfs != nil {
+		abfs, err := NewAbfs(catalogName, connections.Abfs)
+		if err != nil {
+			return err
+		}
+		b.merge(abfs)
+	}
+
+	if connections.Gcs != nil {
+		b.merge(NewGcs(catalogName, connections.Gcs))
+	}
+
+	if connections.Kerberos != nil {
+		kerberos, err := NewKerberos(catalogName, connections.Kerberos, connections.Metastore, connections.Hdfs != nil)
+		if err != nil {
+			return err
+		}
+		b.merge(kerberos)
+	}
+
+	return nil
+}
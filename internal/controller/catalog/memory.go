@@ -0,0 +1,24 @@
+package catalog
+
+import (
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &Memory{}
+
+type Memory struct {
+	baseConnector
+
+	Spec *trinov1alpha1.MemoryConnectorSpec
+}
+
+func NewMemory(spec *trinov1alpha1.MemoryConnectorSpec) *Memory {
+	m := &Memory{
+		baseConnector: newBaseConnector("memory"),
+		Spec:          spec,
+	}
+	if spec.MaxDataPerNode != "" {
+		m.properties.Add("memory.max-data-per-node", spec.MaxDataPerNode)
+	}
+	return m
+}
@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// MetastoreDiscoveryKey is the key of the metastore thrift uri in the discovery ConfigMap
+// created by hive-operator, e.g. thrift://simple-hive-metastore-default-0.simple-hive-metastore-default:9083
+const MetastoreDiscoveryKey = "HIVE"
+
+var _ Connector = &Metastore{}
+
+// Metastore connects a lake catalog to a thrift hive metastore or AWS Glue.
+// The connector selects the metastore type, as the property differs between hive and iceberg.
+type Metastore struct {
+	baseConnector
+
+	Spec *trinov1alpha1.MetastoreConnectionSpec
+}
+
+func NewMetastore(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.MetastoreConnectionSpec,
+) (*Metastore, error) {
+	if spec == nil || (spec.ConfigMap == "") == (spec.Glue == nil) {
+		return nil, fmt.Errorf("catalog %s: exactly one of metastore configMap or glue must be specified", catalogName)
+	}
+
+	m := &Metastore{
+		baseConnector: newConnectorPart(),
+		Spec:          spec,
+	}
+
+	if spec.Glue != nil {
+		if err := m.addGlue(catalogName, spec.Glue); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	metastoreUri, err := getMetastoreUri(ctx, client, catalogName, spec)
+	if err != nil {
+		return nil, err
+	}
+	m.properties.Add("hive.metastore.uri", metastoreUri)
+	return m, nil
+}
+
+// IsGlue tells whether the metastore is AWS Glue.
+func (m *Metastore) IsGlue() bool {
+	return m.Spec.Glue != nil
+}
+
+func (m *Metastore) addGlue(catalogName string, spec *trinov1alpha1.GlueMetastoreSpec) error {
+	if spec.CredentialsSecret != "" && spec.WebIdentity {
+		return fmt.Errorf("catalog %s: glue credentialsSecret and webIdentity can not be combined", catalogName)
+	}
+
+	m.properties.Add("hive.metastore.glue.region", spec.Region)
+	if spec.CatalogId != "" {
+		m.properties.Add("hive.metastore.glue.catalogid", spec.CatalogId)
+	}
+	if spec.Endpoint != "" {
+		m.properties.Add("hive.metastore.glue.endpoint-url", spec.Endpoint)
+	}
+	if spec.IamRole != "" {
+		m.properties.Add("hive.metastore.glue.iam-role", spec.IamRole)
+	}
+
+	if spec.CredentialsSecret != "" {
+		m.properties.Add(
+			"hive.metastore.glue.aws-access-key",
+			m.addSecretEnvVar(getEnvName(catalogName, "GLUE_ACCESS_KEY"), spec.CredentialsSecret, "accessKey"),
+		)
+		m.properties.Add(
+			"hive.metastore.glue.aws-secret-key",
+			m.addSecretEnvVar(getEnvName(catalogName, "GLUE_SECRET_KEY"), spec.CredentialsSecret, "secretKey"),
+		)
+	}
+	if spec.WebIdentity {
+		m.properties.Add("hive.metastore.glue.use-web-identity-token-credentials-provider", strconv.FormatBool(spec.WebIdentity))
+	}
+	return nil
+}
+
+// getMetastoreUri reads the hive metastore thrift uri from the metastore discovery ConfigMap.
+func getMetastoreUri(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *trinov1alpha1.MetastoreConnectionSpec,
+) (string, error) {
+	if spec == nil || spec.ConfigMap == "" {
+		return "", fmt.Errorf("catalog %s: metastore discovery configmap is required", catalogName)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := client.Client.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: client.GetOwnerNamespace(), Name: spec.ConfigMap},
+		cm,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("catalog %s: metastore discovery configmap %s not found", catalogName, spec.ConfigMap)
+		}
+		return "", err
+	}
+
+	uri, ok := cm.Data[MetastoreDiscoveryKey]
+	if !ok || uri == "" {
+		return "", fmt.Errorf(
+			"catalog %s: key %s not found in metastore discovery configmap %s",
+			catalogName, MetastoreDiscoveryKey, spec.ConfigMap,
+		)
+	}
+	return uri, nil
+}
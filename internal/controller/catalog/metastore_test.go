@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// The glue metastore is resolved without reading the cluster, so the connectors are built without client.
+
+func TestHiveGlueMetastore(t *testing.T) {
+	g := NewWithT(t)
+
+	hive, err := NewHive(context.Background(), nil, "lake", &trinov1alpha1.HiveConnectorSpec{
+		Metastore: &trinov1alpha1.MetastoreConnectionSpec{
+			Glue: &trinov1alpha1.GlueMetastoreSpec{
+				Region:            "eu-west-1",
+				CatalogId:         "123456789012",
+				Endpoint:          "https://glue.eu-west-1.amazonaws.com",
+				CredentialsSecret: "glue-credentials",
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	rendered, err := hive.GetConfigProperties().Marshal()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rendered).To(Equal(`connector.name=hive
+hive.metastore=glue
+hive.metastore.glue.aws-access-key=${ENV:CATALOG_LAKE_GLUE_ACCESS_KEY}
+hive.metastore.glue.aws-secret-key=${ENV:CATALOG_LAKE_GLUE_SECRET_KEY}
+hive.metastore.glue.catalogid=123456789012
+hive.metastore.glue.endpoint-url=https://glue.eu-west-1.amazonaws.com
+hive.metastore.glue.region=eu-west-1
+`))
+
+	envNames := make([]string, 0)
+	for _, env := range hive.GetEnvVars() {
+		g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal("glue-credentials"))
+		envNames = append(envNames, env.Name)
+	}
+	g.Expect(envNames).To(ConsistOf("CATALOG_LAKE_GLUE_ACCESS_KEY", "CATALOG_LAKE_GLUE_SECRET_KEY"))
+
+	warnings, err := ValidateProperties("lake", hive.GetConfigProperties())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+}
+
+func TestIcebergGlueMetastore(t *testing.T) {
+	g := NewWithT(t)
+
+	iceberg, err := NewIceberg(context.Background(), nil, "lake", &trinov1alpha1.IcebergConnectorSpec{
+		Metastore: &trinov1alpha1.MetastoreConnectionSpec{
+			Glue: &trinov1alpha1.GlueMetastoreSpec{
+				Region:      "us-east-1",
+				IamRole:     "arn:aws:iam::123456789012:role/trino",
+				WebIdentity: true,
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	rendered, err := iceberg.GetConfigProperties().Marshal()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rendered).To(Equal(`connector.name=iceberg
+hive.metastore.glue.iam-role=arn:aws:iam::123456789012:role/trino
+hive.metastore.glue.region=us-east-1
+hive.metastore.glue.use-web-identity-token-credentials-provider=true
+iceberg.catalog.type=glue
+`))
+	g.Expect(iceberg.GetEnvVars()).To(BeEmpty())
+
+	warnings, err := ValidateProperties("lake", iceberg.GetConfigProperties())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+}
+
+func TestInvalidMetastore(t *testing.T) {
+	tests := map[string]*trinov1alpha1.MetastoreConnectionSpec{
+		"missing":         nil,
+		"empty":           {},
+		"configmap glue":  {ConfigMap: "metastore", Glue: &trinov1alpha1.GlueMetastoreSpec{Region: "us-east-1"}},
+		"glue both creds": {Glue: &trinov1alpha1.GlueMetastoreSpec{Region: "us-east-1", CredentialsSecret: "glue", WebIdentity: true}},
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := NewMetastore(context.Background(), nil, "lake", spec)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// LabelMigratedFrom is the label of a TrinoCatalog migrated from the catalogProperties of a cluster,
+// the value is the name of the cluster.
+const LabelMigratedFrom = "trino.kubedoop.dev/migrated-from"
+
+var invalidSecretKeyRegex = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// MigrationResult is the result of the migration of the legacy catalogProperties of a cluster.
+type MigrationResult struct {
+	// Skipped are the legacy catalogs which can not be migrated, with the reason.
+	Skipped []string
+
+	// Mismatched are the migrated catalogs not rendering the same properties as the legacy catalogs.
+	Mismatched []string
+}
+
+// Complete tells whether all legacy catalogs are migrated to TrinoCatalogs rendering the same properties.
+func (r *MigrationResult) Complete() bool {
+	return len(r.Skipped) == 0 && len(r.Mismatched) == 0
+}
+
+// migratedCatalog is a TrinoCatalog migrated from a legacy catalog, with the Secret of its sensitive values.
+type migratedCatalog struct {
+	catalog *trinov1alpha1.TrinoCatalog
+	secret  *corev1.Secret
+}
+
+// MigrateCatalogProperties converts each legacy catalogProperties entry of the cluster into a TrinoCatalog
+// with a generic connector, then verifies the catalogs rendered from the migrated TrinoCatalogs are byte-identical
+// to the legacy ones. The sensitive values are moved to a Secret referenced by the TrinoCatalog.
+// A legacy catalog which can not be migrated, e.g. its name is not a valid TrinoCatalog name
+// or a TrinoCatalog with the same name exists, is skipped and reported, the other catalogs are migrated.
+func MigrateCatalogProperties(
+	ctx context.Context,
+	client *client.Client,
+	cluster *trinov1alpha1.TrinoCluster,
+) (*MigrationResult, error) {
+	result := &MigrationResult{}
+	clusterConfig := cluster.Spec.ClusterConfig
+	if clusterConfig == nil || len(clusterConfig.CatalogProperties) == 0 {
+		return result, nil
+	}
+
+	catalogLabels, err := getMigrationLabels(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(clusterConfig.CatalogProperties))
+	for name := range clusterConfig.CatalogProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		migrated := newMigratedCatalog(cluster, name, catalogLabels)
+		reason, err := checkMigratedCatalog(ctx, client, cluster, migrated)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %s", name, reason))
+			continue
+		}
+		if err := applyMigratedCatalog(ctx, client, cluster, migrated); err != nil {
+			return nil, err
+		}
+		if !verifyMigratedCatalog(ctx, client, clusterConfig, migrated) {
+			result.Mismatched = append(result.Mismatched, name)
+		}
+	}
+	return result, nil
+}
+
+// getMigrationLabels returns the labels of the migrated TrinoCatalogs, the match labels of the catalog
+// label selector of the cluster. Match expressions are not converted, the labels must satisfy them as is.
+func getMigrationLabels(cluster *trinov1alpha1.TrinoCluster) (map[string]string, error) {
+	selector := cluster.Spec.ClusterConfig.CatalogLabelSelector
+	if selector == nil {
+		return nil, fmt.Errorf("catalogLabelSelector is required to select the migrated catalogs")
+	}
+
+	catalogLabels := map[string]string{LabelMigratedFrom: cluster.Name}
+	for key, value := range selector.MatchLabels {
+		catalogLabels[key] = value
+	}
+
+	labelSelector, err := GetLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if !labelSelector.Matches(labels.Set(catalogLabels)) {
+		return nil, fmt.Errorf("catalogLabelSelector does not select the migrated catalogs, select them with matchLabels")
+	}
+	return catalogLabels, nil
+}
+
+// newMigratedCatalog returns the TrinoCatalog of a legacy catalog and the Secret of its sensitive values,
+// nil when it has no sensitive values.
+func newMigratedCatalog(
+	cluster *trinov1alpha1.TrinoCluster,
+	name string,
+	catalogLabels map[string]string,
+) *migratedCatalog {
+	legacy := cluster.Spec.ClusterConfig.CatalogProperties[name]
+	secretName := name + "-migrated-properties"
+
+	propertyValues := make(map[string]trinov1alpha1.GenericPropertySpec, len(legacy))
+	secretData := make(map[string][]byte)
+	for k, v := range legacy {
+		switch {
+		case k == "connector.name":
+		case IsSensitiveProperty(k, v):
+			secretKey := invalidSecretKeyRegex.ReplaceAllString(k, "_")
+			secretData[secretKey] = []byte(v)
+			propertyValues[k] = trinov1alpha1.GenericPropertySpec{
+				ValueFrom: &trinov1alpha1.GenericPropertyValueFromSpec{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  secretKey,
+					},
+				},
+			}
+		default:
+			propertyValues[k] = trinov1alpha1.GenericPropertySpec{Value: v}
+		}
+	}
+
+	migrated := &migratedCatalog{
+		catalog: &trinov1alpha1.TrinoCatalog{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: name, Labels: catalogLabels},
+			Spec: trinov1alpha1.TrinoCatalogSpec{
+				Connector: trinov1alpha1.ConnectorSpec{
+					Generic: &trinov1alpha1.GenericConnectorSpec{
+						Name:           legacy["connector.name"],
+						PropertyValues: propertyValues,
+					},
+				},
+			},
+		},
+	}
+	if len(secretData) > 0 {
+		migrated.secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      secretName,
+				Labels:    map[string]string{LabelMigratedFrom: cluster.Name},
+			},
+			Data: secretData,
+		}
+	}
+	return migrated
+}
+
+// checkMigratedCatalog returns why the legacy catalog can not be migrated, empty when it can.
+// A TrinoCatalog or Secret with the same name not migrated from the cluster is never changed.
+func checkMigratedCatalog(
+	ctx context.Context,
+	client *client.Client,
+	cluster *trinov1alpha1.TrinoCluster,
+	migrated *migratedCatalog,
+) (string, error) {
+	name := migrated.catalog.Name
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "not a valid TrinoCatalog name, migrate it to a TrinoCatalog exposed with an alias: " +
+			strings.Join(errs, ", "), nil
+	}
+	if migrated.catalog.Spec.Connector.Generic.Name == "" {
+		return "no connector.name", nil
+	}
+
+	reason, err := checkMigratedObject(ctx, client, cluster, "TrinoCatalog", &trinov1alpha1.TrinoCatalog{}, name)
+	if err != nil || reason != "" || migrated.secret == nil {
+		return reason, err
+	}
+	if errs := validation.IsDNS1123Subdomain(migrated.secret.Name); len(errs) > 0 {
+		return "not a valid Secret name for the sensitive values: " + strings.Join(errs, ", "), nil
+	}
+	return checkMigratedObject(ctx, client, cluster, "Secret", &corev1.Secret{}, migrated.secret.Name)
+}
+
+// checkMigratedObject returns why an object of the migration can not be created or updated,
+// an object with the same name exists which is not migrated from the cluster.
+func checkMigratedObject(
+	ctx context.Context,
+	client *client.Client,
+	cluster *trinov1alpha1.TrinoCluster,
+	kind string,
+	obj ctrlclient.Object,
+	name string,
+) (string, error) {
+	key := ctrlclient.ObjectKey{Namespace: cluster.Namespace, Name: name}
+	if err := client.Client.Get(ctx, key, obj); err != nil {
+		return "", ctrlclient.IgnoreNotFound(err)
+	}
+	if obj.GetLabels()[LabelMigratedFrom] != cluster.Name {
+		return fmt.Sprintf("a %s named %s exists", kind, name), nil
+	}
+	return "", nil
+}
+
+// applyMigratedCatalog creates or updates the TrinoCatalog of a legacy catalog and the Secret of its
+// sensitive values. The Secret is controlled by the TrinoCatalog, so it is deleted with it.
+func applyMigratedCatalog(
+	ctx context.Context,
+	client *client.Client,
+	cluster *trinov1alpha1.TrinoCluster,
+	migrated *migratedCatalog,
+) error {
+	obj := &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: migrated.catalog.Namespace, Name: migrated.catalog.Name},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, client.Client, obj, func() error {
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		for k, v := range migrated.catalog.Labels {
+			obj.Labels[k] = v
+		}
+		obj.Spec.Connector = migrated.catalog.Spec.Connector
+		if cluster.Spec.ClusterConfig.CatalogPropertiesMigration == trinov1alpha1.CatalogPropertiesMigrationOwned {
+			return controllerutil.SetControllerReference(cluster, obj, client.Client.Scheme())
+		}
+		if metav1.IsControlledBy(obj, cluster) {
+			return controllerutil.RemoveControllerReference(cluster, obj, client.Client.Scheme())
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if migrated.secret == nil {
+		return nil
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: migrated.secret.Namespace, Name: migrated.secret.Name},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, client.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		for k, v := range migrated.secret.Labels {
+			secret.Labels[k] = v
+		}
+		secret.Data = migrated.secret.Data
+		return controllerutil.SetControllerReference(obj, secret, client.Client.Scheme())
+	})
+	return err
+}
+
+// verifyMigratedCatalog tells whether the migrated TrinoCatalog renders the same properties as the legacy catalog,
+// with the catalog overrides of the cluster. The catalog is rendered from the migrated objects, not read back,
+// and the references to the Secret of the sensitive values are resolved from it.
+func verifyMigratedCatalog(
+	ctx context.Context,
+	client *client.Client,
+	clusterConfig *trinov1alpha1.ClusterConfigSpec,
+	migrated *migratedCatalog,
+) bool {
+	name := migrated.catalog.Name
+	c, err := NewCatalog(ctx, client, migrated.catalog)
+	if err != nil {
+		return false
+	}
+	if override, ok := clusterConfig.CatalogOverrides[name]; ok {
+		c.applyOverride(override)
+	}
+	if c.GetCatalogName() != name {
+		return false
+	}
+
+	secretValues := make(map[string]string)
+	if migrated.secret != nil {
+		for _, env := range c.GetEnvVars() {
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil || env.ValueFrom.SecretKeyRef.Name != migrated.secret.Name {
+				continue
+			}
+			secretValues["${ENV:"+env.Name+"}"] = string(migrated.secret.Data[env.ValueFrom.SecretKeyRef.Key])
+		}
+	}
+
+	rendered := c.GetConfigProperties()
+	resolved := properties.NewProperties()
+	for _, key := range rendered.Keys() {
+		value, _ := rendered.Get(key)
+		if secretValue, ok := secretValues[value]; ok {
+			value = secretValue
+		}
+		resolved.Add(key, value)
+	}
+
+	legacy, err := properties.NewPropertiesFromMap(clusterConfig.CatalogProperties[name]).Marshal()
+	if err != nil {
+		return false
+	}
+	migratedProperties, err := resolved.Marshal()
+	if err != nil {
+		return false
+	}
+	return legacy == migratedProperties
+}
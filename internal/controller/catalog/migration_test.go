@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestMigrateCatalogProperties(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(trinov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &trinov1alpha1.TrinoCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino", UID: "uid"},
+		Spec: trinov1alpha1.TrinoClusterSpec{
+			ClusterConfig: &trinov1alpha1.ClusterConfigSpec{
+				CatalogLabelSelector:       &trinov1alpha1.CatalogLabelSelectorSpec{MatchLabels: map[string]string{"trino": "trino"}},
+				CatalogPropertiesMigration: trinov1alpha1.CatalogPropertiesMigrationOwned,
+				CatalogProperties: map[string]map[string]string{
+					"mysql": {
+						"connector.name":      "mysql",
+						"connection-url":      "jdbc:mysql://mysql:3306",
+						"connection-user":     "trino",
+						"connection-password": "changeme",
+					},
+					"tpch_sf1": {"connector.name": "tpch"},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+	resourceClient := &client.Client{Client: fakeClient, OwnerReference: cluster}
+
+	// The catalogs are verified on the first pass, against the migrated objects.
+	result, err := MigrateCatalogProperties(ctx, resourceClient, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Mismatched).To(BeEmpty())
+	g.Expect(result.Skipped).To(HaveLen(1))
+	g.Expect(result.Skipped[0]).To(HavePrefix("tpch_sf1: "))
+
+	migrated := &trinov1alpha1.TrinoCatalog{}
+	g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "trino", Name: "mysql"}, migrated)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(migrated, cluster)).To(BeTrue())
+	propertyValues := migrated.Spec.Connector.Generic.PropertyValues
+	g.Expect(propertyValues["connection-user"].Value).To(Equal("trino"))
+	g.Expect(propertyValues["connection-password"].Value).To(BeEmpty())
+	g.Expect(propertyValues["connection-password"].ValueFrom.SecretKeyRef.Name).To(Equal("mysql-migrated-properties"))
+
+	secret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "trino", Name: "mysql-migrated-properties"}, secret)).To(Succeed())
+	g.Expect(secret.Data).To(HaveKeyWithValue("connection-password", []byte("changeme")))
+	g.Expect(metav1.IsControlledBy(secret, migrated)).To(BeTrue())
+
+	// A TrinoCatalog with the same name not migrated from the cluster is skipped.
+	cluster.Spec.ClusterConfig.CatalogProperties["tpch"] = map[string]string{"connector.name": "tpch"}
+	g.Expect(fakeClient.Create(ctx, &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "tpch"},
+		Spec: trinov1alpha1.TrinoCatalogSpec{
+			Connector: trinov1alpha1.ConnectorSpec{Generic: &trinov1alpha1.GenericConnectorSpec{Name: "tpch"}},
+		},
+	})).To(Succeed())
+	result, err = MigrateCatalogProperties(ctx, resourceClient, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Mismatched).To(BeEmpty())
+	g.Expect(result.Skipped).To(HaveLen(2))
+	g.Expect(result.Complete()).To(BeFalse())
+}
@@ -0,0 +1,361 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	ReferenceKindConfigMap = "ConfigMap"
+	ReferenceKindSecret    = "Secret"
+
+	// LabelReferenceCopyOf labels the copies of referenced objects with the cluster they are copied for,
+	// so the copies no longer referenced by the catalogs of the cluster are pruned.
+	LabelReferenceCopyOf = "trino.kubedoop.dev/reference-copy-of"
+	// AnnotationReferenceSource is the namespace/name of the source of a copy.
+	AnnotationReferenceSource = "trino.kubedoop.dev/reference-source"
+	// ReferenceSourceIndex is the field index of the copies by source, so a change of a source is mapped to the clusters.
+	ReferenceSourceIndex = AnnotationReferenceSource
+
+	// AllNamespaces in allowedNamespaces allows clusters of all namespaces to consume a catalog.
+	AllNamespaces = "*"
+)
+
+// IsNamespaceAllowed tells whether clusters in the namespace are allowed to consume the catalog.
+func IsNamespaceAllowed(obj *trinov1alpha1.TrinoCatalog, namespace string) bool {
+	if obj.Namespace == namespace {
+		return true
+	}
+	return slices.Contains(obj.Spec.AllowedNamespaces, AllNamespaces) || slices.Contains(obj.Spec.AllowedNamespaces, namespace)
+}
+
+// SelectsCatalog tells whether the cluster selects the catalog with its catalog label selector and namespace selector.
+func SelectsCatalog(
+	ctx context.Context,
+	c ctrlclient.Client,
+	cluster *trinov1alpha1.TrinoCluster,
+	obj *trinov1alpha1.TrinoCatalog,
+) (bool, error) {
+	if cluster.Spec.ClusterConfig == nil {
+		return false, nil
+	}
+	selector := cluster.Spec.ClusterConfig.CatalogLabelSelector
+	labelSelector, err := GetLabelSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	if !labelSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false, nil
+	}
+	if obj.Namespace == cluster.Namespace {
+		return true, nil
+	}
+	if selector.NamespaceSelector == nil || !IsNamespaceAllowed(obj, cluster.Namespace) {
+		return false, nil
+	}
+
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, ctrlclient.ObjectKey{Name: obj.Namespace}, namespace); err != nil {
+		return false, ctrlclient.IgnoreNotFound(err)
+	}
+	return namespaceSelector.Matches(labels.Set(namespace.GetLabels())), nil
+}
+
+// getNamespaceClient returns a client reading objects in the namespace of the owner,
+// used to resolve a catalog in its own namespace.
+func getNamespaceClient(c *client.Client, owner ctrlclient.Object) *client.Client {
+	return client.NewClient(c.Client, owner)
+}
+
+// getCatalogNamespaces returns the namespaces to select catalogs from, the namespace of the cluster comes first.
+func getCatalogNamespaces(
+	ctx context.Context,
+	c ctrlclient.Client,
+	namespace string,
+	selector *trinov1alpha1.CatalogLabelSelectorSpec,
+) ([]string, error) {
+	namespaces := []string{namespace}
+	if selector == nil || selector.NamespaceSelector == nil {
+		return namespaces, nil
+	}
+
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	list := &corev1.NamespaceList{}
+	if err := c.List(ctx, list, ctrlclient.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+		return nil, err
+	}
+	for _, ns := range list.Items {
+		if ns.Name != namespace {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// hostQualifier qualifies the host names of a catalog with the namespace of the catalog, as the kubernetes DNS
+// resolves them in the namespace of the client: `svc` and `pod.svc` are relative to it, `svc.namespace` is not.
+type hostQualifier struct {
+	ctx       context.Context
+	client    ctrlclient.Client
+	namespace string
+}
+
+func (q *hostQualifier) qualify(host string) string {
+	if host == "" || host == "localhost" || net.ParseIP(host) != nil || strings.HasSuffix(host, ".") {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) > 2 || (len(labels) == 2 && q.isNamespace(labels[1])) {
+		return host
+	}
+	return host + "." + q.namespace
+}
+
+// isNamespace tells whether the name is an existing namespace, or can not be checked.
+func (q *hostQualifier) isNamespace(name string) bool {
+	if q.client == nil {
+		return false
+	}
+	err := q.client.Get(q.ctx, ctrlclient.ObjectKey{Name: name}, &corev1.Namespace{})
+	return err == nil || !apierrors.IsNotFound(err)
+}
+
+// qualifyHosts qualifies the hosts the catalog connects to with the namespace of the catalog,
+// so a catalog from another namespace connects to the services of its own namespace.
+func (c *Catalog) qualifyHosts(ctx context.Context, client ctrlclient.Client) {
+	q := &hostQualifier{ctx: ctx, client: client, namespace: c.Namespace}
+	p := c.properties
+	for _, key := range p.Keys() {
+		value, _ := p.Get(key)
+		if qualified := qualifyPropertyHosts(key, value, q.qualify); qualified != value {
+			p.Add(key, qualified)
+		}
+	}
+}
+
+// Reference is a ConfigMap or Secret referenced by a catalog from another namespace,
+// it is copied to the namespace of the cluster, as pods can only reference objects of their own namespace.
+type Reference struct {
+	Kind      string
+	Namespace string
+	Name      string
+	CopyName  string
+}
+
+// getCopyName returns the name of the copy of an object from another namespace.
+// Namespaces contain no dots, so the copies of different namespaces never conflict.
+func getCopyName(namespace string, name string) string {
+	copyName := namespace + "." + name
+	if len(copyName) <= 253 {
+		return copyName
+	}
+	hash := sha256.Sum256([]byte(copyName))
+	return strings.TrimRight(copyName[:244], ".-") + "-" + hex.EncodeToString(hash[:])[:8]
+}
+
+// localize rewrites the ConfigMaps and Secrets referenced by the environment variables and volumes
+// of a catalog from another namespace to their copies, and records the references to copy.
+// A catalog using Secrets searched by secret-operator in the namespace of the pod, e.g. s3 credentials,
+// is rejected: the operator can not tell which Secret of the SecretClass is used, so it copies none of them.
+func (c *Catalog) localize() error {
+	if classes := c.GetSearchedSecretClasses(); len(classes) > 0 {
+		return fmt.Errorf(
+			"catalog %s/%s: the Secrets of SecretClass %s are searched in the namespace of the pod, "+
+				"they can not be used by a catalog from another namespace",
+			c.Namespace, c.Name, strings.Join(classes, ", "),
+		)
+	}
+
+	refs := make(map[Reference]bool)
+	add := func(ref Reference) {
+		if !refs[ref] {
+			refs[ref] = true
+			c.References = append(c.References, ref)
+		}
+	}
+	rename := func(kind string, name string) string {
+		ref := Reference{Kind: kind, Namespace: c.Namespace, Name: name, CopyName: getCopyName(c.Namespace, name)}
+		add(ref)
+		return ref.CopyName
+	}
+
+	// The sources may point to the spec of the catalog, so they are copied before renaming.
+	for i := range c.envVars {
+		if c.envVars[i].ValueFrom == nil {
+			continue
+		}
+		source := c.envVars[i].ValueFrom.DeepCopy()
+		c.envVars[i].ValueFrom = source
+		if source.SecretKeyRef != nil {
+			source.SecretKeyRef.Name = rename(ReferenceKindSecret, source.SecretKeyRef.Name)
+		}
+		if source.ConfigMapKeyRef != nil {
+			source.ConfigMapKeyRef.Name = rename(ReferenceKindConfigMap, source.ConfigMapKeyRef.Name)
+		}
+	}
+
+	for i := range c.volumes {
+		c.volumes[i] = *c.volumes[i].DeepCopy()
+		source := &c.volumes[i].VolumeSource
+		if source.ConfigMap != nil {
+			source.ConfigMap.Name = rename(ReferenceKindConfigMap, source.ConfigMap.Name)
+		}
+		if source.Secret != nil {
+			source.Secret.SecretName = rename(ReferenceKindSecret, source.Secret.SecretName)
+		}
+		if source.Projected != nil {
+			for j := range source.Projected.Sources {
+				projection := &source.Projected.Sources[j]
+				if projection.ConfigMap != nil {
+					projection.ConfigMap.Name = rename(ReferenceKindConfigMap, projection.ConfigMap.Name)
+				}
+				if projection.Secret != nil {
+					projection.Secret.Name = rename(ReferenceKindSecret, projection.Secret.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SyncReferences copies the ConfigMaps and Secrets referenced by catalogs from other namespaces
+// to the namespace of the cluster, and prunes the copies no longer referenced.
+// The copies are owned by the cluster, so they are removed with it.
+func SyncReferences(ctx context.Context, client *client.Client, references []Reference) error {
+	copies := make(map[string]bool)
+	for _, ref := range references {
+		switch ref.Kind {
+		case ReferenceKindSecret:
+			source := &corev1.Secret{}
+			if err := client.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, source); err != nil {
+				return err
+			}
+			if err := syncSecret(ctx, client, ref, source); err != nil {
+				return err
+			}
+			copies[ReferenceKindSecret+"/"+ref.CopyName] = true
+		case ReferenceKindConfigMap:
+			if err := syncConfigMap(ctx, client, ref); err != nil {
+				return err
+			}
+			copies[ReferenceKindConfigMap+"/"+ref.CopyName] = true
+		}
+	}
+	return pruneReferences(ctx, client, copies)
+}
+
+// pruneReferences deletes the copies made for the cluster which are not in copies, keyed by kind/name,
+// e.g. when a catalog is no longer selected or no longer allows the namespace of the cluster.
+func pruneReferences(ctx context.Context, client *client.Client, copies map[string]bool) error {
+	owner := client.OwnerReference
+	opts := []ctrlclient.ListOption{
+		ctrlclient.InNamespace(client.GetOwnerNamespace()),
+		ctrlclient.MatchingLabels{LabelReferenceCopyOf: owner.GetName()},
+	}
+
+	objs := make(map[string]ctrlclient.Object)
+	secrets := &corev1.SecretList{}
+	if err := client.Client.List(ctx, secrets, opts...); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		objs[ReferenceKindSecret+"/"+secrets.Items[i].Name] = &secrets.Items[i]
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := client.Client.List(ctx, configMaps, opts...); err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		objs[ReferenceKindConfigMap+"/"+configMaps.Items[i].Name] = &configMaps.Items[i]
+	}
+
+	for key, obj := range objs {
+		if copies[key] || !metav1.IsControlledBy(obj, owner) {
+			continue
+		}
+		if err := client.Client.Delete(ctx, obj); ctrlclient.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCopyMeta labels the copy with the cluster, annotates it with its source, and sets the cluster as owner.
+func setCopyMeta(client *client.Client, ref Reference, target ctrlclient.Object) error {
+	copyLabels := target.GetLabels()
+	if copyLabels == nil {
+		copyLabels = make(map[string]string)
+	}
+	copyLabels[LabelReferenceCopyOf] = client.OwnerReference.GetName()
+	target.SetLabels(copyLabels)
+
+	annotations := target.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationReferenceSource] = ref.Namespace + "/" + ref.Name
+	target.SetAnnotations(annotations)
+
+	return controllerutil.SetControllerReference(client.OwnerReference, target, client.Client.Scheme())
+}
+
+// GetReferenceSource returns the index key of a copy, the namespace/name of its source.
+func GetReferenceSource(obj ctrlclient.Object) []string {
+	source, ok := obj.GetAnnotations()[AnnotationReferenceSource]
+	if !ok {
+		return nil
+	}
+	return []string{source}
+}
+
+// syncSecret copies the data of the source Secret. The labels are not copied,
+// so a copy is never found by a secret-operator search in the namespace of the cluster.
+func syncSecret(ctx context.Context, client *client.Client, ref Reference, source *corev1.Secret) error {
+	target := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: client.GetOwnerNamespace(), Name: ref.CopyName},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, client.Client, target, func() error {
+		target.Data = source.Data
+		return setCopyMeta(client, ref, target)
+	})
+	return err
+}
+
+func syncConfigMap(ctx context.Context, client *client.Client, ref Reference) error {
+	source := &corev1.ConfigMap{}
+	if err := client.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, source); err != nil {
+		return err
+	}
+
+	target := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: client.GetOwnerNamespace(), Name: ref.CopyName},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, client.Client, target, func() error {
+		target.Data = source.Data
+		target.BinaryData = source.BinaryData
+		return setCopyMeta(client, ref, target)
+	})
+	return err
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestQualifyPropertyHosts(t *testing.T) {
+	q := &hostQualifier{ctx: context.Background(), namespace: "lake"}
+	tests := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{key: "hive.metastore.uri", value: "thrift://metastore:9083", expected: "thrift://metastore.lake:9083"},
+		{key: "hive.metastore.uri", value: "thrift://hive-0.hive:9083", expected: "thrift://hive-0.hive.lake:9083"},
+		{
+			key:      "hive.metastore.uri",
+			value:    "thrift://hive.lake.svc.cluster.local:9083",
+			expected: "thrift://hive.lake.svc.cluster.local:9083",
+		},
+		{key: "connection-url", value: "jdbc:postgresql://db:5432/trino", expected: "jdbc:postgresql://db.lake:5432/trino"},
+		{key: "kafka.nodes", value: "kafka-0:9092, 10.0.0.1:9092", expected: "kafka-0.lake:9092,10.0.0.1:9092"},
+		{key: "elasticsearch.host", value: "search", expected: "search.lake"},
+		{key: "hive.metastore.uri", value: "${ENV:METASTORE_URI}", expected: "${ENV:METASTORE_URI}"},
+		{key: "connector.name", value: "hive", expected: "hive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(qualifyPropertyHosts(tt.key, tt.value, q.qualify)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestSyncReferences(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(trinov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &trinov1alpha1.TrinoCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino", UID: "uid"}}
+	classLabels := map[string]string{constants.AnnotationSecretsClass: "s3"}
+	// A copy of a Secret of the class made by a previous version, it is no longer referenced.
+	staleCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "trino",
+			Name:        "lake.s3-credentials",
+			Labels:      map[string]string{constants.AnnotationSecretsClass: "s3", LabelReferenceCopyOf: "trino"},
+			Annotations: map[string]string{AnnotationReferenceSource: "lake/s3-credentials"},
+		},
+	}
+	g.Expect(controllerutil.SetControllerReference(cluster, staleCopy, scheme)).To(Succeed())
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "lake", Name: "db-credentials", Labels: classLabels},
+			Data:       map[string][]byte{"password": []byte("changeme")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "lake", Name: "s3-credentials", Labels: classLabels},
+			Data:       map[string][]byte{"ACCESS_KEY": []byte("access"), "SECRET_KEY": []byte("secret")},
+		},
+		staleCopy,
+	).Build()
+	resourceClient := &client.Client{Client: fakeClient, OwnerReference: cluster}
+
+	references := []Reference{
+		{Kind: ReferenceKindSecret, Namespace: "lake", Name: "db-credentials", CopyName: getCopyName("lake", "db-credentials")},
+	}
+	g.Expect(SyncReferences(ctx, resourceClient, references)).To(Succeed())
+
+	// Only the referenced Secret is copied, without its labels, so secret-operator never finds the copy.
+	secret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "trino", Name: "lake.db-credentials"}, secret)).To(Succeed())
+	g.Expect(secret.Data).To(HaveKeyWithValue("password", []byte("changeme")))
+	g.Expect(secret.Labels).To(HaveKeyWithValue(LabelReferenceCopyOf, "trino"))
+	g.Expect(secret.Labels).NotTo(HaveKey(constants.AnnotationSecretsClass))
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(AnnotationReferenceSource, "lake/db-credentials"))
+
+	// The unrelated Secret of the same class is not copied, and its stale copy is pruned.
+	err := fakeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "trino", Name: "lake.s3-credentials"}, secret)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The copies are pruned once the catalog no longer references them.
+	g.Expect(SyncReferences(ctx, resourceClient, nil)).To(Succeed())
+	err = fakeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: "trino", Name: "lake.db-credentials"}, secret)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+// The s3 credentials are searched by secret-operator in the namespace of the pod,
+// so a catalog using them is not consumed from another namespace.
+func TestLocalizeSearchedSecretClass(t *testing.T) {
+	g := NewWithT(t)
+
+	s3, err := NewS3(context.Background(), nil, "lake", &s3v1alpha1.S3BucketSpec{
+		Connection: &s3v1alpha1.S3BucketConnectionSpec{
+			Inline: &s3v1alpha1.S3ConnectionSpec{
+				Host:        "minio",
+				Credentials: &commonsv1alpha1.Credentials{SecretClass: "s3"},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	c := &Catalog{baseConnector: newConnectorPart(), Name: "lake", Namespace: "lake"}
+	c.merge(s3)
+	g.Expect(c.localize()).To(MatchError(ContainSubstring("SecretClass s3")))
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"strconv"
+	"strings"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
+)
+
+const (
+	DefaultMongoDBSchemaCollection = "_schema"
+	DefaultCassandraPort           = 9042
+)
+
+var _ Connector = &MongoDB{}
+
+type MongoDB struct {
+	baseConnector
+
+	Spec *trinov1alpha1.MongoDBConnectorSpec
+}
+
+func NewMongoDB(catalogName string, spec *trinov1alpha1.MongoDBConnectorSpec) *MongoDB {
+	m := &MongoDB{
+		baseConnector: newBaseConnector("mongodb"),
+		Spec:          spec,
+	}
+
+	schemaCollection := spec.SchemaCollection
+	if schemaCollection == "" {
+		schemaCollection = DefaultMongoDBSchemaCollection
+	}
+
+	m.properties.Add(
+		"mongodb.connection-url",
+		m.addSecretEnvVar(getEnvName(catalogName, "MONGODB_CONNECTION_URL"), spec.ConnectionUrlSecret, "connectionUrl"),
+	)
+	m.properties.Add("mongodb.schema-collection", schemaCollection)
+
+	if spec.Tls != nil {
+		m.properties.Add("mongodb.tls.enabled", "true")
+		m.addCaCert(getVolumeName(catalogName, "mongodb-tls-ca"), getMountPath(catalogName, "mongodb-tls-ca"), spec.Tls.Verification)
+		m.properties.Add("mongodb.tls.truststore-path", truststore.Path)
+		m.properties.Add("mongodb.tls.truststore-password", truststore.Passphrase)
+	}
+
+	return m
+}
+
+var _ Connector = &Cassandra{}
+
+type Cassandra struct {
+	baseConnector
+
+	Spec *trinov1alpha1.CassandraConnectorSpec
+}
+
+func NewCassandra(catalogName string, spec *trinov1alpha1.CassandraConnectorSpec) *Cassandra {
+	c := &Cassandra{
+		baseConnector: newBaseConnector("cassandra"),
+		Spec:          spec,
+	}
+
+	port := spec.Port
+	if port == 0 {
+		port = DefaultCassandraPort
+	}
+
+	c.properties.Add("cassandra.contact-points", strings.Join(spec.ContactPoints, ","))
+	c.properties.Add("cassandra.native-protocol-port", strconv.Itoa(int(port)))
+	c.properties.Add("cassandra.load-policy.dc-aware.local-dc", spec.Datacenter)
+
+	if spec.CredentialsSecret != "" {
+		c.properties.Add(
+			"cassandra.username",
+			c.addSecretEnvVar(getEnvName(catalogName, "CASSANDRA_USERNAME"), spec.CredentialsSecret, "username"),
+		)
+		c.properties.Add(
+			"cassandra.password",
+			c.addSecretEnvVar(getEnvName(catalogName, "CASSANDRA_PASSWORD"), spec.CredentialsSecret, "password"),
+		)
+	}
+
+	if spec.Tls != nil {
+		c.properties.Add("cassandra.tls.enabled", "true")
+		c.addCaCert(getVolumeName(catalogName, "cassandra-tls-ca"), getMountPath(catalogName, "cassandra-tls-ca"), spec.Tls.Verification)
+		c.properties.Add("cassandra.tls.truststore-path", truststore.Path)
+		c.properties.Add("cassandra.tls.truststore-password", truststore.Passphrase)
+	}
+
+	return c
+}
+
+var _ Connector = &Redis{}
+
+type Redis struct {
+	baseConnector
+
+	Spec *trinov1alpha1.RedisConnectorSpec
+}
+
+func NewRedis(catalogName string, spec *trinov1alpha1.RedisConnectorSpec) *Redis {
+	r := &Redis{
+		baseConnector: newBaseConnector("redis"),
+		Spec:          spec,
+	}
+
+	r.properties.Add("redis.nodes", strings.Join(spec.Nodes, ","))
+	if spec.DefaultSchema != "" {
+		r.properties.Add("redis.default-schema", spec.DefaultSchema)
+	}
+	if spec.DatabaseIndex != nil {
+		r.properties.Add("redis.database-index", strconv.Itoa(int(*spec.DatabaseIndex)))
+	}
+	if spec.PasswordSecret != "" {
+		r.properties.Add(
+			"redis.password",
+			r.addSecretEnvVar(getEnvName(catalogName, "REDIS_PASSWORD"), spec.PasswordSecret, "password"),
+		)
+	}
+
+	if len(spec.TableDescriptions) > 0 {
+		volumeName := getVolumeName(catalogName, "redis-table-descriptions")
+		mountPath := getMountPath(catalogName, "redis-table-descriptions")
+		r.addConfigMapsVolume(volumeName, mountPath, spec.TableDescriptions)
+		r.properties.Add("redis.table-description-dir", mountPath)
+	}
+
+	return r
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+	"k8s.io/utils/ptr"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestNoSQL(t *testing.T) {
+	secretClassVerification := &commonsv1alpha1.TLSVerificationSpec{
+		Server: &commonsv1alpha1.ServerVerification{
+			CACert: &commonsv1alpha1.CACert{SecretClass: "tls"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		connector    Connector
+		want         string
+		wantSecret   string
+		wantEnvNames []string
+		wantVolumes  int
+	}{
+		{
+			name: "mongodb",
+			connector: NewMongoDB("store", &trinov1alpha1.MongoDBConnectorSpec{
+				ConnectionUrlSecret: "mongodb-connection",
+			}),
+			want: `connector.name=mongodb
+mongodb.connection-url=${ENV:CATALOG_STORE_MONGODB_CONNECTION_URL}
+mongodb.schema-collection=_schema
+`,
+			wantSecret:   "mongodb-connection",
+			wantEnvNames: []string{"CATALOG_STORE_MONGODB_CONNECTION_URL"},
+		},
+		{
+			name: "mongodb with tls",
+			connector: NewMongoDB("store", &trinov1alpha1.MongoDBConnectorSpec{
+				ConnectionUrlSecret: "mongodb-connection",
+				SchemaCollection:    "schemas",
+				Tls:                 &trinov1alpha1.CatalogTlsSpec{Verification: secretClassVerification},
+			}),
+			want: `connector.name=mongodb
+mongodb.connection-url=${ENV:CATALOG_STORE_MONGODB_CONNECTION_URL}
+mongodb.schema-collection=schemas
+mongodb.tls.enabled=true
+mongodb.tls.truststore-password=changeit
+mongodb.tls.truststore-path=/kubedoop/tls/client/truststore.p12
+`,
+			wantSecret:   "mongodb-connection",
+			wantEnvNames: []string{"CATALOG_STORE_MONGODB_CONNECTION_URL"},
+			wantVolumes:  1,
+		},
+		{
+			name: "cassandra",
+			connector: NewCassandra("store", &trinov1alpha1.CassandraConnectorSpec{
+				ContactPoints: []string{"cassandra-0.cassandra", "cassandra-1.cassandra"},
+				Datacenter:    "dc1",
+			}),
+			want: `cassandra.contact-points=cassandra-0.cassandra,cassandra-1.cassandra
+cassandra.load-policy.dc-aware.local-dc=dc1
+cassandra.native-protocol-port=9042
+connector.name=cassandra
+`,
+		},
+		{
+			name: "cassandra with credentials and tls",
+			connector: NewCassandra("store", &trinov1alpha1.CassandraConnectorSpec{
+				ContactPoints:     []string{"cassandra"},
+				Port:              9142,
+				Datacenter:        "dc1",
+				CredentialsSecret: "cassandra-credentials",
+				Tls:               &trinov1alpha1.CatalogTlsSpec{Verification: secretClassVerification},
+			}),
+			want: `cassandra.contact-points=cassandra
+cassandra.load-policy.dc-aware.local-dc=dc1
+cassandra.native-protocol-port=9142
+cassandra.password=${ENV:CATALOG_STORE_CASSANDRA_PASSWORD}
+cassandra.tls.enabled=true
+cassandra.tls.truststore-password=changeit
+cassandra.tls.truststore-path=/kubedoop/tls/client/truststore.p12
+cassandra.username=${ENV:CATALOG_STORE_CASSANDRA_USERNAME}
+connector.name=cassandra
+`,
+			wantSecret:   "cassandra-credentials",
+			wantEnvNames: []string{"CATALOG_STORE_CASSANDRA_USERNAME", "CATALOG_STORE_CASSANDRA_PASSWORD"},
+			wantVolumes:  1,
+		},
+		{
+			name: "redis",
+			connector: NewRedis("store", &trinov1alpha1.RedisConnectorSpec{
+				Nodes: []string{"redis:6379"},
+			}),
+			want: `connector.name=redis
+redis.nodes=redis:6379
+`,
+		},
+		{
+			name: "redis with password and table descriptions",
+			connector: NewRedis("store", &trinov1alpha1.RedisConnectorSpec{
+				Nodes:             []string{"redis-0.redis:6379", "redis-1.redis:6379"},
+				DefaultSchema:     "cache",
+				DatabaseIndex:     ptr.To[int32](2),
+				PasswordSecret:    "redis-credentials",
+				TableDescriptions: []string{"sessions-table"},
+			}),
+			want: `connector.name=redis
+redis.database-index=2
+redis.default-schema=cache
+redis.nodes=redis-0.redis:6379,redis-1.redis:6379
+redis.password=${ENV:CATALOG_STORE_REDIS_PASSWORD}
+redis.table-description-dir=/kubedoop/catalog/store/redis-table-descriptions
+`,
+			wantSecret:   "redis-credentials",
+			wantEnvNames: []string{"CATALOG_STORE_REDIS_PASSWORD"},
+			wantVolumes:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			rendered, err := tt.connector.GetConfigProperties().Marshal()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(tt.want))
+
+			envNames := make([]string, 0)
+			for _, env := range tt.connector.GetEnvVars() {
+				g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal(tt.wantSecret))
+				envNames = append(envNames, env.Name)
+			}
+			g.Expect(envNames).To(ConsistOf(tt.wantEnvNames))
+			g.Expect(tt.connector.GetVolumes()).To(HaveLen(tt.wantVolumes))
+
+			warnings, err := ValidateProperties("store", tt.connector.GetConfigProperties())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const DefaultPreflightTimeout = 5 * time.Second
+
+// defaultPorts are the ports of the url schemes used when an address has no port.
+var defaultPorts = map[string]int{
+	"thrift":  9083,
+	"mongodb": 27017,
+	"http":    80,
+	"https":   443,
+}
+
+// urlProperties are the properties whose value is a comma separated list of urls the catalog connects to.
+var urlProperties = []string{
+	"hive.metastore.uri",
+	"s3.endpoint",
+	"mongodb.connection-url",
+	"iceberg.rest-catalog.uri",
+	"iceberg.nessie-catalog.uri",
+	"connection-url",
+	"iceberg.jdbc-catalog.connection-url",
+}
+
+// addressListProperties are the properties whose value is a comma separated list of host:port addresses.
+var addressListProperties = []string{"kafka.nodes", "redis.nodes"}
+
+// hostListProperty is a property whose value is a comma separated list of hosts, with the property of the port.
+type hostListProperty struct {
+	key         string
+	portKey     string
+	defaultPort int
+}
+
+var hostListProperties = []hostListProperty{
+	{key: "cassandra.contact-points", portKey: "cassandra.native-protocol-port", defaultPort: DefaultCassandraPort},
+	{key: "elasticsearch.host", portKey: "elasticsearch.port", defaultPort: 9200},
+	{key: "opensearch.host", portKey: "opensearch.port", defaultPort: 9200},
+}
+
+// IsHeld tells whether the catalog is held back from the consuming clusters,
+// because its preflight checks did not pass for its current generation.
+func IsHeld(obj *trinov1alpha1.TrinoCatalog) bool {
+	if obj.Spec.Preflight == nil || !obj.Spec.Preflight.HoldRollout {
+		return false
+	}
+	// A catalog released before the hold was enabled has no released spec to keep.
+	if obj.Status.ReleasedSpec == nil && obj.Status.ReleasedGeneration > 0 {
+		return false
+	}
+	condition := apimeta.FindStatusCondition(obj.Status.Conditions, trinov1alpha1.CatalogConditionTypePreflightPassed)
+	if condition == nil || condition.ObservedGeneration != obj.GetGeneration() {
+		return true
+	}
+	// The checks disabled by the operator can not pass, so they do not hold the catalog back.
+	return condition.Status != metav1.ConditionTrue && condition.Reason != trinov1alpha1.CatalogConditionReasonPreflightDisabled
+}
+
+// GetReleased returns the catalog to render to the consuming clusters. A held catalog is rendered
+// with its last released spec, nil when it was never released.
+func GetReleased(obj *trinov1alpha1.TrinoCatalog) *trinov1alpha1.TrinoCatalog {
+	if !IsHeld(obj) {
+		return obj
+	}
+	if obj.Status.ReleasedSpec == nil {
+		return nil
+	}
+	released := obj.DeepCopy()
+	released.Spec = *obj.Status.ReleasedSpec
+	released.Generation = obj.Status.ReleasedGeneration
+	return released
+}
+
+// Preflight checks that the Services the catalog connects to are reachable over TCP, and returns the addresses
+// which are not checked. Only the ports declared by a Service of the cluster are dialed, so a catalog can not
+// probe arbitrary hosts and ports from the operator: addresses outside of the cluster, e.g. an external host or
+// an ip, and addresses referencing an environment variable or a file are not checked.
+// Short service names are resolved in the namespace of the catalog, as the operator runs in another namespace,
+// the client is used to tell a `svc.namespace` name from a `pod.svc` name.
+// The errors only tell which address is not reachable, the cause is not reported.
+func Preflight(
+	ctx context.Context,
+	client ctrlclient.Client,
+	namespace string,
+	spec *trinov1alpha1.CatalogPreflightSpec,
+	p *properties.Properties,
+) ([]string, error) {
+	timeout := DefaultPreflightTimeout
+	if spec.Timeout != "" {
+		t, err := time.ParseDuration(spec.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preflight timeout %s: %w", spec.Timeout, err)
+		}
+		timeout = t
+	}
+
+	q := &hostQualifier{ctx: ctx, client: client, namespace: namespace}
+	dialer := &net.Dialer{Timeout: timeout}
+	skipped := make([]string, 0)
+	errs := make([]error, 0)
+	for _, address := range getPreflightAddresses(p) {
+		target, ok, err := getServiceAddress(ctx, client, qualifyAddress(address, q.qualify))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			skipped = append(skipped, address)
+			continue
+		}
+		if target == "" {
+			errs = append(errs, fmt.Errorf("%s is not reachable", address))
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s is not reachable", address))
+			continue
+		}
+		_ = conn.Close()
+	}
+	return skipped, errors.Join(errs...)
+}
+
+// getServiceAddress returns the address to dial for a `svc.namespace`, `svc.namespace.svc[.domain]`
+// or `pod.svc.namespace.svc[.domain]` address, false when the address is not one of a Service.
+// The address is empty when the Service does not exist, is an ExternalName, or does not declare the port.
+// The address is built from the Service, not from the host of the catalog: the cluster ip of the Service,
+// or the DNS name of a headless Service.
+func getServiceAddress(ctx context.Context, client ctrlclient.Client, address string) (string, bool, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return "", false, nil
+	}
+
+	pod := ""
+	labels := strings.Split(host, ".")
+	switch {
+	case len(labels) == 2 || (len(labels) > 2 && labels[2] == "svc"):
+	case len(labels) > 3 && labels[3] == "svc":
+		pod, labels = labels[0], labels[1:]
+	default:
+		return "", false, nil
+	}
+
+	svc := &corev1.Service{}
+	if err := client.Get(ctx, ctrlclient.ObjectKey{Namespace: labels[1], Name: labels[0]}, svc); err != nil {
+		return "", true, ctrlclient.IgnoreNotFound(err)
+	}
+	declared := slices.ContainsFunc(svc.Spec.Ports, func(p corev1.ServicePort) bool {
+		return strconv.Itoa(int(p.Port)) == port
+	})
+	if !declared || svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return "", true, nil
+	}
+
+	name := svc.Name + "." + svc.Namespace + ".svc"
+	switch {
+	case pod != "":
+		name = pod + "." + name
+	case svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != corev1.ClusterIPNone:
+		name = svc.Spec.ClusterIP
+	}
+	return net.JoinHostPort(name, port), true, nil
+}
+
+// getPreflightAddresses returns the host:port addresses the catalog connects to, found in its properties.
+// Urls of an unknown format, e.g. a jdbc url of a driver not known by the operator, are skipped.
+func getPreflightAddresses(p *properties.Properties) []string {
+	addresses := make([]string, 0)
+	add := func(address string) {
+		for _, a := range addresses {
+			if a == address {
+				return
+			}
+		}
+		addresses = append(addresses, address)
+	}
+
+	for _, key := range urlProperties {
+		value, ok := p.Get(key)
+		if !ok || value == "" || strings.Contains(value, "${") {
+			continue
+		}
+		for _, rawUrl := range strings.Split(value, ",") {
+			if address, err := getUrlAddress(strings.TrimSpace(rawUrl)); err == nil {
+				add(address)
+			}
+		}
+	}
+
+	for _, key := range addressListProperties {
+		nodes, ok := p.Get(key)
+		if !ok || strings.Contains(nodes, "${") {
+			continue
+		}
+		for _, node := range strings.Split(nodes, ",") {
+			add(strings.TrimSpace(node))
+		}
+	}
+
+	for _, property := range hostListProperties {
+		hosts, ok := p.Get(property.key)
+		if !ok || strings.Contains(hosts, "${") {
+			continue
+		}
+		port, _ := p.Get(property.portKey)
+		if port == "" {
+			port = strconv.Itoa(property.defaultPort)
+		}
+		for _, host := range strings.Split(hosts, ",") {
+			add(net.JoinHostPort(strings.TrimSpace(host), port))
+		}
+	}
+
+	return addresses
+}
+
+// qualifyPropertyHosts rewrites the hosts of a property the catalog connects to with qualify,
+// other properties and values referencing an environment variable or a file are returned as is.
+func qualifyPropertyHosts(key string, value string, qualify func(string) string) string {
+	if value == "" || strings.Contains(value, "${") {
+		return value
+	}
+
+	var rewrite func(string) string
+	switch {
+	case slices.Contains(urlProperties, key):
+		rewrite = func(rawUrl string) string { return qualifyUrl(rawUrl, qualify) }
+	case slices.Contains(addressListProperties, key):
+		rewrite = func(address string) string { return qualifyAddress(address, qualify) }
+	case slices.ContainsFunc(hostListProperties, func(p hostListProperty) bool { return p.key == key }):
+		rewrite = qualify
+	default:
+		return value
+	}
+
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = rewrite(strings.TrimSpace(item))
+	}
+	return strings.Join(items, ",")
+}
+
+// qualifyUrl rewrites the host of a url with qualify, urls of an unknown format are returned as is.
+func qualifyUrl(rawUrl string, qualify func(string) string) string {
+	address, err := getUrlAddress(rawUrl)
+	if err != nil {
+		return rawUrl
+	}
+	host, _, _ := net.SplitHostPort(address)
+	qualified := qualify(host)
+	i := strings.Index(rawUrl, "//")
+	if qualified == host || i < 0 {
+		return rawUrl
+	}
+	rest := rawUrl[i+2:]
+	j := strings.Index(rest, host)
+	if j < 0 {
+		return rawUrl
+	}
+	return rawUrl[:i+2] + rest[:j] + qualified + rest[j+len(host):]
+}
+
+// getUrlAddress returns the host:port address of a url, e.g. thrift://metastore:9083 or
+// jdbc:sqlserver://db:1433;encrypt=true. The default port of the scheme is used when the url has no port.
+func getUrlAddress(rawUrl string) (string, error) {
+	rawUrl = strings.TrimPrefix(rawUrl, "jdbc:")
+	// sqlserver separates the properties of the url with semicolons
+	if i := strings.Index(rawUrl, ";"); i >= 0 {
+		rawUrl = rawUrl[:i]
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("%s has no host", rawUrl)
+	}
+
+	port := u.Port()
+	if port == "" {
+		defaultPort, ok := defaultPorts[u.Scheme]
+		if !ok {
+			database, ok := jdbcDatabases[u.Scheme]
+			if !ok {
+				return "", fmt.Errorf("%s has no port", rawUrl)
+			}
+			defaultPort = int(database.defaultPort)
+		}
+		port = strconv.Itoa(defaultPort)
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// qualifyAddress rewrites the host of a host:port address with qualify.
+func qualifyAddress(address string, qualify func(string) string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return net.JoinHostPort(qualify(host), port)
+}
@@ -0,0 +1,176 @@
+package catalog
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func newTpchSpec(splits string) trinov1alpha1.TrinoCatalogSpec {
+	return trinov1alpha1.TrinoCatalogSpec{
+		Connector: trinov1alpha1.ConnectorSpec{
+			Generic: &trinov1alpha1.GenericConnectorSpec{
+				Name: "tpch",
+				PropertyValues: map[string]trinov1alpha1.GenericPropertySpec{
+					"tpch.splits-per-node": {Value: splits},
+				},
+			},
+		},
+		Preflight: &trinov1alpha1.CatalogPreflightSpec{HoldRollout: true},
+	}
+}
+
+func TestNewCatalogsHeld(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(trinov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	labels := map[string]string{"trino": "trino"}
+	passed := metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypePreflightPassed,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.CatalogConditionReasonPreflightPassed,
+		ObservedGeneration: 1,
+	}
+	releasedSpec := newTpchSpec("4")
+
+	// The existing catalog is edited, its new generation did not pass its preflight checks yet.
+	existing := &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "tpch", Labels: labels, Generation: 2},
+		Spec:       newTpchSpec("8"),
+		Status: trinov1alpha1.TrinoCatalogStatus{
+			Conditions:         []metav1.Condition{passed},
+			ReleasedGeneration: 1,
+			ReleasedSpec:       &releasedSpec,
+		},
+	}
+	// The new catalog was never released.
+	created := &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "fresh", Labels: labels, Generation: 1},
+		Spec:       newTpchSpec("8"),
+	}
+	// The hold is enabled on a catalog released without it, so there is no released spec to keep.
+	enabled := &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "enabled", Labels: labels, Generation: 2},
+		Spec:       newTpchSpec("16"),
+		Status:     trinov1alpha1.TrinoCatalogStatus{ReleasedGeneration: 1},
+	}
+
+	cluster := &trinov1alpha1.TrinoCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing, created, enabled).Build()
+	resourceClient := &client.Client{Client: fakeClient, OwnerReference: cluster}
+
+	catalogs, err := NewCatalogs(ctx, resourceClient, &trinov1alpha1.ClusterConfigSpec{
+		CatalogLabelSelector: &trinov1alpha1.CatalogLabelSelectorSpec{MatchLabels: labels},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(catalogs.Held).To(ConsistOf("tpch", "fresh"))
+
+	catalogProperties := catalogs.GetCatalogProperties()
+	g.Expect(catalogProperties).To(HaveLen(2))
+	g.Expect(catalogProperties).To(HaveKey("tpch"))
+	splits, _ := catalogProperties["tpch"].Get("tpch.splits-per-node")
+	g.Expect(splits).To(Equal("4"))
+	splits, _ = catalogProperties["enabled"].Get("tpch.splits-per-node")
+	g.Expect(splits).To(Equal("16"))
+	g.Expect(catalogs.GetGeneration("tpch")).To(Equal(int64(1)))
+}
+
+func TestPreflight(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer func() { _ = listener.Close() }()
+	_, listenerPort, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(listenerPort)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	// The metastore Service is dialed on its cluster ip, the local listener.
+	metastore := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hive", Name: "metastore"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "127.0.0.1",
+			Ports:     []corev1.ServicePort{{Port: int32(port)}},
+		},
+	}
+	external := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "db"},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: "db.example.com",
+			Ports:        []corev1.ServicePort{{Port: 5432}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		metastore,
+		external,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "hive"}},
+	).Build()
+
+	tests := []struct {
+		name    string
+		p       map[string]string
+		skipped []string
+		err     string
+	}{
+		{
+			name: "service port",
+			p:    map[string]string{"hive.metastore.uri": "thrift://metastore.hive:" + listenerPort},
+		},
+		{
+			name: "service fqdn",
+			p:    map[string]string{"hive.metastore.uri": "thrift://metastore.hive.svc.cluster.local:" + listenerPort},
+		},
+		{
+			name: "undeclared port",
+			p:    map[string]string{"hive.metastore.uri": "thrift://metastore.hive:22"},
+			err:  "metastore.hive:22 is not reachable",
+		},
+		{
+			name: "missing service",
+			p:    map[string]string{"hive.metastore.uri": "thrift://metastore:9083"},
+			err:  "metastore:9083 is not reachable",
+		},
+		{
+			name: "external name service",
+			p:    map[string]string{"connection-url": "jdbc:postgresql://db:5432/trino"},
+			err:  "db:5432 is not reachable",
+		},
+		{
+			name:    "outside of the cluster",
+			p:       map[string]string{"connection-url": "jdbc:postgresql://db.example.com:5432/trino,jdbc:postgresql://10.0.0.1/trino"},
+			skipped: []string{"db.example.com:5432", "10.0.0.1:5432"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			skipped, err := Preflight(ctx, fakeClient, "trino", &trinov1alpha1.CatalogPreflightSpec{}, properties.NewPropertiesFromMap(tt.p))
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(skipped).To(Equal(append([]string{}, tt.skipped...)))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
+	"github.com/zncdatadev/operator-go/pkg/builder"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	S3AccessKeyName = "ACCESS_KEY"
+	S3SecretKeyName = "SECRET_KEY"
+)
+
+var _ Connector = &S3{}
+
+// S3 renders a s3 bucket connection into trino native s3 file system properties.
+// The credentials are provided by secret-operator from the SecretClass of the connection,
+// and exported to environment variables when the container starts.
+type S3 struct {
+	baseConnector
+
+	CatalogName string
+	Connection  *s3v1alpha1.S3ConnectionSpec
+}
+
+func NewS3(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *s3v1alpha1.S3BucketSpec,
+) (*S3, error) {
+	connection, err := getS3Connection(ctx, client, catalogName, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &S3{
+		baseConnector: newConnectorPart(),
+		CatalogName:   catalogName,
+		Connection:    connection,
+	}
+
+	s.properties.Add("fs.native-s3.enabled", "true")
+	s.properties.Add("s3.endpoint", s.getEndpoint())
+	if connection.Region != "" {
+		s.properties.Add("s3.region", connection.Region)
+	}
+	s.properties.Add("s3.path-style-access", strconv.FormatBool(connection.PathStyle))
+
+	if connection.Credentials != nil {
+		s.addCredentials(connection.Credentials)
+	}
+
+	if connection.Tls != nil {
+		s.addCaCert(getVolumeName(catalogName, "s3-tls-ca"), getMountPath(catalogName, "s3-tls-ca"), connection.Tls.Verification)
+	}
+
+	return s, nil
+}
+
+// getS3Connection returns the inline connection, or the referenced S3Connection in the owner namespace.
+func getS3Connection(
+	ctx context.Context,
+	client *client.Client,
+	catalogName string,
+	spec *s3v1alpha1.S3BucketSpec,
+) (*s3v1alpha1.S3ConnectionSpec, error) {
+	if spec.Connection == nil {
+		return nil, fmt.Errorf("catalog %s: s3 connection is required", catalogName)
+	}
+	if spec.Connection.Inline != nil {
+		return spec.Connection.Inline, nil
+	}
+	if spec.Connection.Reference == "" {
+		return nil, fmt.Errorf("catalog %s: either inline or reference of s3 connection is required", catalogName)
+	}
+
+	obj := &s3v1alpha1.S3Connection{}
+	if err := client.Client.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: client.GetOwnerNamespace(), Name: spec.Connection.Reference},
+		obj,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("catalog %s: s3 connection %s not found", catalogName, spec.Connection.Reference)
+		}
+		return nil, err
+	}
+	return &obj.Spec, nil
+}
+
+func (s *S3) getEndpoint() string {
+	scheme := "http"
+	if s.Connection.Tls != nil {
+		scheme = "https"
+	}
+	host := s.Connection.Host
+	if s.Connection.Port != 0 {
+		host = host + ":" + strconv.Itoa(s.Connection.Port)
+	}
+	return scheme + "://" + host
+}
+
+func (s *S3) addCredentials(credentials *commonsv1alpha1.Credentials) {
+	volumeName := getVolumeName(s.CatalogName, "s3-credentials")
+	mountPath := getMountPath(s.CatalogName, "s3-credentials")
+
+	volume := builder.NewSecretOperatorVolume(volumeName, credentials.SecretClass)
+	if credentials.Scope != nil {
+		volume.SetScope(&builder.SecretVolumeScope{
+			Pod:            credentials.Scope.Pod,
+			Node:           credentials.Scope.Node,
+			Service:        credentials.Scope.Services,
+			ListenerVolume: credentials.Scope.ListenerVolumes,
+		})
+	}
+	s.volumes = append(s.volumes, *volume.Builde())
+	s.volumeMounts = append(s.volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath})
+	s.searchedSecretClasses = append(s.searchedSecretClasses, credentials.SecretClass)
+
+	accessKeyEnvName := getEnvName(s.CatalogName, "S3_ACCESS_KEY")
+	secretKeyEnvName := getEnvName(s.CatalogName, "S3_SECRET_KEY")
+	s.properties.Add("s3.aws-access-key", fmt.Sprintf("${ENV:%s}", accessKeyEnvName))
+	s.properties.Add("s3.aws-secret-key", fmt.Sprintf("${ENV:%s}", secretKeyEnvName))
+
+	cmd := `
+set +x
+
+export ` + accessKeyEnvName + `=$(cat ` + path.Join(mountPath, S3AccessKeyName) + `)
+export ` + secretKeyEnvName + `=$(cat ` + path.Join(mountPath, S3SecretKeyName) + `)
+set -x
+`
+	s.commands = append(s.commands, util.IndentTab4Spaces(cmd))
+}
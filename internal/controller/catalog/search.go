@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
+)
+
+const (
+	DefaultSearchPort   = 9200
+	DefaultSearchSchema = "default"
+)
+
+var _ Connector = &Search{}
+
+// Search is a catalog of an elasticsearch or opensearch cluster. The connector name
+// is also the prefix of the connector properties, e.g. elasticsearch.host or opensearch.host.
+type Search struct {
+	baseConnector
+
+	CatalogName string
+	Spec        *trinov1alpha1.SearchConnectorSpec
+}
+
+// NewSearch resolves the connector, the server certificate is always verified, as the connector
+// can only skip the hostname verification.
+func NewSearch(catalogName string, connectorName string, spec *trinov1alpha1.SearchConnectorSpec) (*Search, error) {
+	if spec.Tls != nil && spec.Tls.Verification != nil && spec.Tls.Verification.None != nil {
+		return nil, fmt.Errorf("catalog %s: tls verification none is not supported by %s connector", catalogName, connectorName)
+	}
+
+	s := &Search{
+		baseConnector: newBaseConnector(connectorName),
+		CatalogName:   catalogName,
+		Spec:          spec,
+	}
+
+	port := spec.Port
+	if port == 0 {
+		port = DefaultSearchPort
+	}
+	defaultSchema := spec.DefaultSchema
+	if defaultSchema == "" {
+		defaultSchema = DefaultSearchSchema
+	}
+
+	prefix := connectorName + "."
+	s.properties.Add(prefix+"host", strings.Join(spec.Hosts, ","))
+	s.properties.Add(prefix+"port", strconv.Itoa(int(port)))
+	s.properties.Add(prefix+"default-schema-name", defaultSchema)
+
+	if spec.CredentialsSecret != "" {
+		s.properties.Add(prefix+"security", "PASSWORD")
+		s.properties.Add(
+			prefix+"auth.user",
+			s.addSecretEnvVar(getEnvName(catalogName, "SEARCH_USERNAME"), spec.CredentialsSecret, "username"),
+		)
+		s.properties.Add(
+			prefix+"auth.password",
+			s.addSecretEnvVar(getEnvName(catalogName, "SEARCH_PASSWORD"), spec.CredentialsSecret, "password"),
+		)
+	}
+
+	if spec.Tls != nil {
+		s.properties.Add(prefix+"tls.enabled", "true")
+		s.addCaCert(getVolumeName(catalogName, "search-tls-ca"), getMountPath(catalogName, "search-tls-ca"), spec.Tls.Verification)
+		s.properties.Add(prefix+"tls.truststore-path", truststore.Path)
+		s.properties.Add(prefix+"tls.truststore-password", truststore.Passphrase)
+	}
+
+	return s, nil
+}
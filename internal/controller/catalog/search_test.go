@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name          string
+		connectorName string
+		spec          *trinov1alpha1.SearchConnectorSpec
+		want          string
+		wantEnvNames  []string
+		wantVolumes   int
+		wantErr       string
+	}{
+		{
+			name:          "elasticsearch",
+			connectorName: "elasticsearch",
+			spec:          &trinov1alpha1.SearchConnectorSpec{Hosts: []string{"es-0.es", "es-1.es"}},
+			want: `connector.name=elasticsearch
+elasticsearch.default-schema-name=default
+elasticsearch.host=es-0.es,es-1.es
+elasticsearch.port=9200
+`,
+		},
+		{
+			name:          "elasticsearch with credentials and secret class CA",
+			connectorName: "elasticsearch",
+			spec: &trinov1alpha1.SearchConnectorSpec{
+				Hosts:             []string{"es"},
+				Port:              9243,
+				DefaultSchema:     "logs",
+				CredentialsSecret: "es-credentials",
+				Tls: &trinov1alpha1.CatalogTlsSpec{
+					Verification: &commonsv1alpha1.TLSVerificationSpec{
+						Server: &commonsv1alpha1.ServerVerification{
+							CACert: &commonsv1alpha1.CACert{SecretClass: "tls"},
+						},
+					},
+				},
+			},
+			want: `connector.name=elasticsearch
+elasticsearch.auth.password=${ENV:CATALOG_LOGS_SEARCH_PASSWORD}
+elasticsearch.auth.user=${ENV:CATALOG_LOGS_SEARCH_USERNAME}
+elasticsearch.default-schema-name=logs
+elasticsearch.host=es
+elasticsearch.port=9243
+elasticsearch.security=PASSWORD
+elasticsearch.tls.enabled=true
+elasticsearch.tls.truststore-password=changeit
+elasticsearch.tls.truststore-path=/kubedoop/tls/client/truststore.p12
+`,
+			wantEnvNames: []string{"CATALOG_LOGS_SEARCH_USERNAME", "CATALOG_LOGS_SEARCH_PASSWORD"},
+			wantVolumes:  1,
+		},
+		{
+			name:          "opensearch with system CAs",
+			connectorName: "opensearch",
+			spec: &trinov1alpha1.SearchConnectorSpec{
+				Hosts: []string{"opensearch"},
+				Tls: &trinov1alpha1.CatalogTlsSpec{
+					Verification: &commonsv1alpha1.TLSVerificationSpec{
+						Server: &commonsv1alpha1.ServerVerification{
+							CACert: &commonsv1alpha1.CACert{WebPki: &commonsv1alpha1.WebPki{}},
+						},
+					},
+				},
+			},
+			want: `connector.name=opensearch
+opensearch.default-schema-name=default
+opensearch.host=opensearch
+opensearch.port=9200
+opensearch.tls.enabled=true
+opensearch.tls.truststore-password=changeit
+opensearch.tls.truststore-path=/kubedoop/tls/client/truststore.p12
+`,
+		},
+		{
+			name:          "elasticsearch without verification",
+			connectorName: "elasticsearch",
+			spec: &trinov1alpha1.SearchConnectorSpec{
+				Hosts: []string{"es"},
+				Tls: &trinov1alpha1.CatalogTlsSpec{
+					Verification: &commonsv1alpha1.TLSVerificationSpec{None: &commonsv1alpha1.NoneVerification{}},
+				},
+			},
+			wantErr: "catalog logs: tls verification none is not supported by elasticsearch connector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			search, err := NewSearch("logs", tt.connectorName, tt.spec)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			rendered, err := search.GetConfigProperties().Marshal()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(tt.want))
+
+			envNames := make([]string, 0)
+			for _, env := range search.GetEnvVars() {
+				g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal(tt.spec.CredentialsSecret))
+				envNames = append(envNames, env.Name)
+			}
+			g.Expect(envNames).To(ConsistOf(tt.wantEnvNames))
+			g.Expect(search.GetVolumes()).To(HaveLen(tt.wantVolumes))
+
+			warnings, err := ValidateProperties("logs", search.GetConfigProperties())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
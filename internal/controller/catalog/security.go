@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	CatalogSecurityAllowAll    = "allow-all"
+	CatalogSecurityReadOnly    = "read-only"
+	CatalogSecurityFile        = "file"
+	CatalogSecuritySqlStandard = "sql-standard"
+	CatalogSecuritySystem      = "system"
+
+	// CatalogSecurityRulesFile is the key of the catalog rules in the rules ConfigMap.
+	CatalogSecurityRulesFile = "rules.json"
+)
+
+// catalogSecurityProperties are the security property of each connector supporting catalog security.
+var catalogSecurityProperties = map[string]string{
+	"hive":       "hive.security",
+	"iceberg":    "iceberg.security",
+	"delta_lake": "delta.security",
+}
+
+// catalogSecurityModes are the security modes supported by each connector.
+var catalogSecurityModes = map[string][]string{
+	"hive": {
+		CatalogSecurityAllowAll,
+		CatalogSecurityReadOnly,
+		CatalogSecurityFile,
+		CatalogSecuritySqlStandard,
+		CatalogSecuritySystem,
+	},
+	"iceberg":    {CatalogSecurityAllowAll, CatalogSecurityReadOnly, CatalogSecurityFile, CatalogSecuritySystem},
+	"delta_lake": {CatalogSecurityAllowAll, CatalogSecurityReadOnly, CatalogSecurityFile, CatalogSecuritySystem},
+}
+
+var _ Connector = &CatalogSecurity{}
+
+// CatalogSecurity is the access control of a hive, iceberg or delta lake catalog.
+// The rules of the file mode are mounted from a ConfigMap.
+// The modes are restricted per connector by the CRD, they are checked again for objects created before.
+type CatalogSecurity struct {
+	baseConnector
+
+	Mode string
+	Spec *trinov1alpha1.CatalogSecurityRulesSpec
+}
+
+func NewCatalogSecurity(
+	connectorName string,
+	catalogName string,
+	mode string,
+	spec *trinov1alpha1.CatalogSecurityRulesSpec,
+) (*CatalogSecurity, error) {
+	if mode == "" {
+		mode = CatalogSecurityAllowAll
+	}
+	if !slices.Contains(catalogSecurityModes[connectorName], mode) {
+		return nil, fmt.Errorf("catalog %s: security mode %s is not supported by the %s connector", catalogName, mode, connectorName)
+	}
+
+	s := &CatalogSecurity{
+		baseConnector: newConnectorPart(),
+		Mode:          mode,
+		Spec:          spec,
+	}
+
+	// hive accepts the lowercase mode, iceberg and delta lake the name of the enum
+	value := mode
+	if connectorName != "hive" {
+		value = strings.ToUpper(strings.ReplaceAll(mode, "-", "_"))
+	}
+	s.properties.Add(catalogSecurityProperties[connectorName], value)
+
+	if mode != CatalogSecurityFile {
+		if spec.RulesConfigMap != "" || spec.RefreshPeriod != "" {
+			return nil, fmt.Errorf("catalog %s: security rulesConfigMap and refreshPeriod require the file mode", catalogName)
+		}
+		return s, nil
+	}
+
+	if spec.RulesConfigMap == "" {
+		return nil, fmt.Errorf("catalog %s: security rulesConfigMap is required for the file mode", catalogName)
+	}
+	volumeName := getVolumeName(catalogName, "security")
+	mountPath := getMountPath(catalogName, "security")
+	s.volumes = append(s.volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: spec.RulesConfigMap},
+				Items:                []corev1.KeyToPath{{Key: CatalogSecurityRulesFile, Path: CatalogSecurityRulesFile}},
+			},
+		},
+	})
+	s.volumeMounts = append(s.volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath, ReadOnly: true})
+	s.properties.Add("security.config-file", path.Join(mountPath, CatalogSecurityRulesFile))
+	if spec.RefreshPeriod != "" {
+		s.properties.Add("security.refresh-period", spec.RefreshPeriod)
+	}
+	return s, nil
+}
@@ -0,0 +1,43 @@
+package catalog
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// SensitivePropertySuffixes are the suffixes of property keys whose values are sensitive.
+	// Keys only ending with `key` are not matched, e.g. `cassandra.partition-key`, but key names like
+	// `s3.aws-secret-key` or `http-server.https.keystore.key` are.
+	SensitivePropertySuffixes = []string{"password", "secret", "token", "access-key", "secret-key", "json-key", ".key"}
+
+	// SensitivePropertyKeys are the sensitive properties not matched by SensitivePropertySuffixes.
+	SensitivePropertyKeys = []string{
+		"iceberg.rest-catalog.oauth2.credential",
+		"sasl.jaas.config",
+	}
+
+	// propertyReferenceRegex matches a value only made of environment variable or file references,
+	// optionally joined by separators, e.g. `${ENV:CLIENT_ID}:${ENV:CLIENT_SECRET}`.
+	propertyReferenceRegex = regexp.MustCompile(`^(\$\{(ENV|FILE):[^}]+\}|[:;,\s])+$`)
+)
+
+// IsSensitiveProperty tells whether the value of the property is sensitive, so it is not rendered in plain text.
+// Values only made of environment variable or file references are not sensitive, they are resolved by trino,
+// which does not resolve the references in the content of a referenced file.
+func IsSensitiveProperty(key string, value string) bool {
+	if value == "" || propertyReferenceRegex.MatchString(value) {
+		return false
+	}
+	if slices.Contains(SensitivePropertyKeys, key) {
+		return true
+	}
+	lowerKey := strings.ToLower(key)
+	for _, suffix := range SensitivePropertySuffixes {
+		if strings.HasSuffix(lowerKey, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+const (
+	DefaultAbfsOAuthEndpoint = "https://login.microsoftonline.com/"
+
+	// GcsKeyFile is the key of the service account json key in the gcs key secret.
+	GcsKeyFile = "key.json"
+)
+
+var _ Connector = &Abfs{}
+
+// Abfs enables the native azure file system of a catalog for Azure Data Lake Storage Gen2.
+// The storage account is taken from the table location, so it is not configured.
+type Abfs struct {
+	baseConnector
+
+	Spec *trinov1alpha1.AbfsConnectionSpec
+}
+
+func NewAbfs(catalogName string, spec *trinov1alpha1.AbfsConnectionSpec) (*Abfs, error) {
+	if (spec.AccessKeySecret == "") == (spec.OAuth == nil) {
+		return nil, fmt.Errorf("catalog %s: exactly one of abfs accessKeySecret or oauth must be specified", catalogName)
+	}
+
+	a := &Abfs{
+		baseConnector: newConnectorPart(),
+		Spec:          spec,
+	}
+	a.properties.Add("fs.native-azure.enabled", "true")
+
+	if spec.AccessKeySecret != "" {
+		a.properties.Add("azure.auth-type", "ACCESS_KEY")
+		a.properties.Add(
+			"azure.access-key",
+			a.addSecretEnvVar(getEnvName(catalogName, "AZURE_ACCESS_KEY"), spec.AccessKeySecret, "accessKey"),
+		)
+		return a, nil
+	}
+
+	endpoint := spec.OAuth.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultAbfsOAuthEndpoint
+	}
+	a.properties.Add("azure.auth-type", "OAUTH")
+	a.properties.Add("azure.oauth.tenant-id", spec.OAuth.TenantId)
+	a.properties.Add("azure.oauth.endpoint", endpoint)
+	a.properties.Add(
+		"azure.oauth.client-id",
+		a.addSecretEnvVar(getEnvName(catalogName, "AZURE_CLIENT_ID"), spec.OAuth.CredentialsSecret, "clientId"),
+	)
+	a.properties.Add(
+		"azure.oauth.secret",
+		a.addSecretEnvVar(getEnvName(catalogName, "AZURE_CLIENT_SECRET"), spec.OAuth.CredentialsSecret, "clientSecret"),
+	)
+	return a, nil
+}
+
+var _ Connector = &Gcs{}
+
+// Gcs enables the native google cloud storage file system of a catalog.
+// The service account key is mounted as file, so it is never rendered into the catalog properties.
+type Gcs struct {
+	baseConnector
+
+	Spec *trinov1alpha1.GcsConnectionSpec
+}
+
+func NewGcs(catalogName string, spec *trinov1alpha1.GcsConnectionSpec) *Gcs {
+	g := &Gcs{
+		baseConnector: newConnectorPart(),
+		Spec:          spec,
+	}
+	g.properties.Add("fs.native-gcs.enabled", "true")
+	if spec.ProjectId != "" {
+		g.properties.Add("gcs.project-id", spec.ProjectId)
+	}
+
+	if spec.KeySecret != "" {
+		volumeName := getVolumeName(catalogName, "gcs-key")
+		mountPath := getMountPath(catalogName, "gcs-key")
+		g.volumes = append(g.volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: spec.KeySecret,
+					Items:      []corev1.KeyToPath{{Key: GcsKeyFile, Path: GcsKeyFile}},
+				},
+			},
+		})
+		g.volumeMounts = append(g.volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mountPath, ReadOnly: true})
+		g.properties.Add("gcs.json-key-file-path", path.Join(mountPath, GcsKeyFile))
+	}
+	return g
+}
@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// The storages are rendered with a glue metastore, so the catalogs are built without client.
+
+func TestLakeStorage(t *testing.T) {
+	tests := []struct {
+		name         string
+		abfs         *trinov1alpha1.AbfsConnectionSpec
+		gcs          *trinov1alpha1.GcsConnectionSpec
+		want         string
+		wantSecret   string
+		wantEnvNames []string
+		wantVolumes  int
+		wantErr      string
+	}{
+		{
+			name: "abfs access key",
+			abfs: &trinov1alpha1.AbfsConnectionSpec{AccessKeySecret: "azure-credentials"},
+			want: `azure.access-key=${ENV:CATALOG_LAKE_AZURE_ACCESS_KEY}
+azure.auth-type=ACCESS_KEY
+connector.name=hive
+fs.native-azure.enabled=true
+hive.metastore=glue
+hive.metastore.glue.region=eu-west-1
+`,
+			wantSecret:   "azure-credentials",
+			wantEnvNames: []string{"CATALOG_LAKE_AZURE_ACCESS_KEY"},
+		},
+		{
+			name: "abfs oauth",
+			abfs: &trinov1alpha1.AbfsConnectionSpec{
+				OAuth: &trinov1alpha1.AbfsOAuthSpec{
+					TenantId:          "00000000-0000-0000-0000-000000000000",
+					CredentialsSecret: "azure-oauth",
+				},
+			},
+			want: `azure.auth-type=OAUTH
+azure.oauth.client-id=${ENV:CATALOG_LAKE_AZURE_CLIENT_ID}
+azure.oauth.endpoint=https://login.microsoftonline.com/
+azure.oauth.secret=${ENV:CATALOG_LAKE_AZURE_CLIENT_SECRET}
+azure.oauth.tenant-id=00000000-0000-0000-0000-000000000000
+connector.name=hive
+fs.native-azure.enabled=true
+hive.metastore=glue
+hive.metastore.glue.region=eu-west-1
+`,
+			wantSecret:   "azure-oauth",
+			wantEnvNames: []string{"CATALOG_LAKE_AZURE_CLIENT_ID", "CATALOG_LAKE_AZURE_CLIENT_SECRET"},
+		},
+		{
+			name:    "abfs without credentials",
+			abfs:    &trinov1alpha1.AbfsConnectionSpec{},
+			wantErr: "catalog lake: exactly one of abfs accessKeySecret or oauth must be specified",
+		},
+		{
+			name: "gcs key",
+			gcs:  &trinov1alpha1.GcsConnectionSpec{ProjectId: "analytics", KeySecret: "gcs-key"},
+			want: `connector.name=hive
+fs.native-gcs.enabled=true
+gcs.json-key-file-path=/kubedoop/catalog/lake/gcs-key/key.json
+gcs.project-id=analytics
+hive.metastore=glue
+hive.metastore.glue.region=eu-west-1
+`,
+			wantVolumes: 1,
+		},
+		{
+			name: "gcs default credentials",
+			gcs:  &trinov1alpha1.GcsConnectionSpec{},
+			want: `connector.name=hive
+fs.native-gcs.enabled=true
+hive.metastore=glue
+hive.metastore.glue.region=eu-west-1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hive, err := NewHive(context.Background(), nil, "lake", &trinov1alpha1.HiveConnectorSpec{
+				Metastore: &trinov1alpha1.MetastoreConnectionSpec{
+					Glue: &trinov1alpha1.GlueMetastoreSpec{Region: "eu-west-1"},
+				},
+				Abfs: tt.abfs,
+				Gcs:  tt.gcs,
+			})
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			rendered, err := hive.GetConfigProperties().Marshal()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(tt.want))
+
+			envNames := make([]string, 0)
+			for _, env := range hive.GetEnvVars() {
+				g.Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal(tt.wantSecret))
+				envNames = append(envNames, env.Name)
+			}
+			g.Expect(envNames).To(ConsistOf(tt.wantEnvNames))
+			g.Expect(hive.GetVolumes()).To(HaveLen(tt.wantVolumes))
+
+			warnings, err := ValidateProperties("lake", hive.GetConfigProperties())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"strconv"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+var _ Connector = &Tpch{}
+
+type Tpch struct {
+	baseConnector
+
+	Spec *trinov1alpha1.TpchConnectorSpec
+}
+
+func NewTpch(spec *trinov1alpha1.TpchConnectorSpec) *Tpch {
+	t := &Tpch{
+		baseConnector: newBaseConnector("tpch"),
+		Spec:          spec,
+	}
+	if spec.SplitsPerNode != nil {
+		t.properties.Add("tpch.splits-per-node", strconv.Itoa(int(*spec.SplitsPerNode)))
+	}
+	if spec.ColumnNaming != "" {
+		t.properties.Add("tpch.column-naming", spec.ColumnNaming)
+	}
+	return t
+}
+
+var _ Connector = &Tpcds{}
+
+type Tpcds struct {
+	baseConnector
+
+	Spec *trinov1alpha1.TpcdsConnectorSpec
+}
+
+func NewTpcds(spec *trinov1alpha1.TpcdsConnectorSpec) *Tpcds {
+	t := &Tpcds{
+		baseConnector: newBaseConnector("tpcds"),
+		Spec:          spec,
+	}
+	if spec.SplitsPerNode != nil {
+		t.properties.Add("tpcds.splits-per-node", strconv.Itoa(int(*spec.SplitsPerNode)))
+	}
+	return t
+}
@@ -0,0 +1,866 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+)
+
+// PropertyType is the type of the value of a catalog property.
+type PropertyType string
+
+const (
+	PropertyTypeString   PropertyType = "string"
+	PropertyTypeBoolean  PropertyType = "boolean"
+	PropertyTypeInteger  PropertyType = "integer"
+	PropertyTypeDuration PropertyType = "duration"
+	PropertyTypeDataSize PropertyType = "data size"
+	PropertyTypeEnum     PropertyType = "enum"
+)
+
+var (
+	durationRegex = regexp.MustCompile(`^\d+(\.\d+)?\s*(ns|us|ms|s|m|h|d)$`)
+	dataSizeRegex = regexp.MustCompile(`^\d+(\.\d+)?\s*(B|kB|MB|GB|TB|PB)$`)
+
+	// propertyNameRegex matches the property names rendered as is to a properties file,
+	// the CRDs check the same for configOverrides and propertyValues.
+	propertyNameRegex = regexp.MustCompile(`^[^\s=:]+$`)
+)
+
+// PropertySpec describes the value of a known catalog property.
+type PropertySpec struct {
+	Type PropertyType
+	// Values are the allowed values of an enum property, compared case insensitively.
+	Values []string
+}
+
+// validate returns why the value is not valid for the property, empty if it is valid.
+func (s PropertySpec) validate(value string) string {
+	switch s.Type {
+	case PropertyTypeBoolean:
+		if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+			return "must be true or false"
+		}
+	case PropertyTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "must be an integer"
+		}
+	case PropertyTypeDuration:
+		if !durationRegex.MatchString(value) {
+			return "must be a duration, e.g. 10s or 5m"
+		}
+	case PropertyTypeDataSize:
+		if !dataSizeRegex.MatchString(value) {
+			return "must be a data size, e.g. 64MB or 1GB"
+		}
+	case PropertyTypeEnum:
+		if !slices.ContainsFunc(s.Values, func(v string) bool { return strings.EqualFold(v, value) }) {
+			return "must be one of " + strings.Join(s.Values, ", ")
+		}
+	}
+	return ""
+}
+
+// propertyRule is a constraint between properties, checked when the key has the value.
+type propertyRule struct {
+	Key string
+	// Value is compared case insensitively, an empty value matches any value.
+	Value string
+	// Requires are the properties which must be set.
+	Requires []string
+	// Excludes are the properties which must not be set.
+	Excludes []string
+}
+
+func (r propertyRule) matches(p *properties.Properties) bool {
+	value, ok := p.Get(r.Key)
+	return ok && (r.Value == "" || strings.EqualFold(r.Value, value))
+}
+
+func (r propertyRule) condition() string {
+	if r.Value == "" {
+		return r.Key + " is set"
+	}
+	return fmt.Sprintf("%s is %s", r.Key, r.Value)
+}
+
+// connectorSchema is the known properties of a connector and the constraints between them.
+type connectorSchema struct {
+	properties map[string]PropertySpec
+	rules      []propertyRule
+}
+
+func newConnectorSchema(rules []propertyRule, groups ...map[string]PropertySpec) connectorSchema {
+	schema := connectorSchema{properties: map[string]PropertySpec{}, rules: rules}
+	for _, group := range groups {
+		for key, spec := range group {
+			schema.properties[key] = spec
+		}
+	}
+	return schema
+}
+
+var (
+	stringProperty   = PropertySpec{Type: PropertyTypeString}
+	booleanProperty  = PropertySpec{Type: PropertyTypeBoolean}
+	integerProperty  = PropertySpec{Type: PropertyTypeInteger}
+	durationProperty = PropertySpec{Type: PropertyTypeDuration}
+	dataSizeProperty = PropertySpec{Type: PropertyTypeDataSize}
+)
+
+func enumProperty(values ...string) PropertySpec {
+	return PropertySpec{Type: PropertyTypeEnum, Values: values}
+}
+
+var compressionCodecProperty = enumProperty("NONE", "SNAPPY", "LZ4", "ZSTD", "GZIP")
+
+var fileSystemProperties = map[string]PropertySpec{
+	"fs.native-s3.enabled":    booleanProperty,
+	"fs.native-azure.enabled": booleanProperty,
+	"fs.native-gcs.enabled":   booleanProperty,
+	"fs.hadoop.enabled":       booleanProperty,
+	"fs.cache.enabled":        booleanProperty,
+	"fs.cache.directories":    stringProperty,
+	"fs.cache.ttl":            durationProperty,
+	"s3.endpoint":             stringProperty,
+	"s3.region":               stringProperty,
+	"s3.path-style-access":    booleanProperty,
+	"s3.aws-access-key":       stringProperty,
+	"s3.aws-secret-key":       stringProperty,
+	"s3.iam-role":             stringProperty,
+	"s3.external-id":          stringProperty,
+	"s3.sts.endpoint":         stringProperty,
+	"s3.sts.region":           stringProperty,
+	"s3.sse.type":             enumProperty("NONE", "S3", "KMS"),
+	"s3.sse.kms-key-id":       stringProperty,
+	"s3.streaming.part-size":  dataSizeProperty,
+	"s3.max-connections":      integerProperty,
+	"hive.config.resources":   stringProperty,
+
+	"azure.auth-type":              enumProperty("ACCESS_KEY", "OAUTH", "DEFAULT"),
+	"azure.access-key":             stringProperty,
+	"azure.oauth.tenant-id":        stringProperty,
+	"azure.oauth.endpoint":         stringProperty,
+	"azure.oauth.client-id":        stringProperty,
+	"azure.oauth.secret":           stringProperty,
+	"azure.read-block-size":        dataSizeProperty,
+	"azure.write-block-size":       dataSizeProperty,
+	"azure.max-write-concurrency":  integerProperty,
+	"azure.max-single-upload-size": dataSizeProperty,
+	"gcs.project-id":               stringProperty,
+	"gcs.json-key":                 stringProperty,
+	"gcs.json-key-file-path":       stringProperty,
+	"gcs.use-access-token":         booleanProperty,
+	"gcs.page-size":                integerProperty,
+	"gcs.batch-size":               integerProperty,
+	"gcs.read-block-size":          dataSizeProperty,
+	"gcs.write-block-size":         dataSizeProperty,
+
+	"hive.hdfs.authentication.type":      enumProperty("NONE", "KERBEROS"),
+	"hive.hdfs.impersonation.enabled":    booleanProperty,
+	"hive.hdfs.trino.principal":          stringProperty,
+	"hive.hdfs.trino.keytab":             stringProperty,
+	"hive.hdfs.wire-encryption.enabled":  booleanProperty,
+	"hive.dfs.replication":               integerProperty,
+	"hive.dfs.verify-checksum":           booleanProperty,
+	"hive.dfs-timeout":                   durationProperty,
+	"hive.dfs.connect.timeout":           durationProperty,
+	"hive.dfs.connect.max-retries":       integerProperty,
+	"hive.dfs.key-provider.cache-ttl":    durationProperty,
+	"hive.dfs.domain-socket-path":        stringProperty,
+	"hive.fs.new-directory-permissions":  stringProperty,
+	"hive.fs.new-file-inherit-ownership": booleanProperty,
+}
+
+// legacyFileSystemProperties are the properties of the legacy file systems, still used by legacy catalogProperties.
+var legacyFileSystemProperties = map[string]PropertySpec{
+	"hive.s3.aws-access-key":          stringProperty,
+	"hive.s3.aws-secret-key":          stringProperty,
+	"hive.s3.endpoint":                stringProperty,
+	"hive.s3.region":                  stringProperty,
+	"hive.s3.iam-role":                stringProperty,
+	"hive.s3.path-style-access":       booleanProperty,
+	"hive.s3.ssl.enabled":             booleanProperty,
+	"hive.s3.sse.enabled":             booleanProperty,
+	"hive.s3.max-connections":         integerProperty,
+	"hive.azure.abfs-storage-account": stringProperty,
+	"hive.azure.abfs-access-key":      stringProperty,
+	"hive.gcs.json-key-file-path":     stringProperty,
+	"hive.gcs.use-access-token":       booleanProperty,
+}
+
+var metastoreProperties = map[string]PropertySpec{
+	"hive.metastore":                                                  enumProperty("thrift", "glue", "file"),
+	"hive.metastore.uri":                                              stringProperty,
+	"hive.metastore.username":                                         stringProperty,
+	"hive.metastore-timeout":                                          durationProperty,
+	"hive.metastore-cache-ttl":                                        durationProperty,
+	"hive.metastore-stats-cache-ttl":                                  durationProperty,
+	"hive.metastore-refresh-interval":                                 durationProperty,
+	"hive.metastore-cache-maximum-size":                               integerProperty,
+	"hive.metastore.thrift.client.connect-timeout":                    durationProperty,
+	"hive.metastore.thrift.client.read-timeout":                       durationProperty,
+	"hive.metastore.thrift.impersonation.enabled":                     booleanProperty,
+	"hive.metastore.thrift.delete-files-on-drop":                      booleanProperty,
+	"hive.metastore.authentication.type":                              enumProperty("NONE", "KERBEROS"),
+	"hive.metastore.service.principal":                                stringProperty,
+	"hive.metastore.client.principal":                                 stringProperty,
+	"hive.metastore.client.keytab":                                    stringProperty,
+	"hive.metastore.catalog.dir":                                      stringProperty,
+	"hive.metastore.thrift.client.ssl.enabled":                        booleanProperty,
+	"hive.metastore.thrift.client.ssl.trust-certificate":              stringProperty,
+	"hive.metastore.thrift.client.ssl.trust-certificate-password":     stringProperty,
+	"hive.metastore.glue.region":                                      stringProperty,
+	"hive.metastore.glue.endpoint-url":                                stringProperty,
+	"hive.metastore.glue.catalogid":                                   stringProperty,
+	"hive.metastore.glue.iam-role":                                    stringProperty,
+	"hive.metastore.glue.external-id":                                 stringProperty,
+	"hive.metastore.glue.aws-access-key":                              stringProperty,
+	"hive.metastore.glue.aws-secret-key":                              stringProperty,
+	"hive.metastore.glue.use-web-identity-token-credentials-provider": booleanProperty,
+	"hive.metastore.glue.pin-client-to-current-region":                booleanProperty,
+	"hive.metastore.glue.sts.region":                                  stringProperty,
+	"hive.metastore.glue.sts.endpoint":                                stringProperty,
+	"hive.metastore.glue.max-connections":                             integerProperty,
+	"hive.metastore.glue.max-error-retries":                           integerProperty,
+	"hive.metastore.glue.default-warehouse-dir":                       stringProperty,
+	"hive.metastore.glue.skip-archive":                                booleanProperty,
+	"hive.metastore.glue.partitions-segments":                         integerProperty,
+	"hive.metastore.glue.get-partition-threads":                       integerProperty,
+	"hive.metastore.glue.read-statistics-threads":                     integerProperty,
+	"hive.metastore.glue.write-statistics-threads":                    integerProperty,
+}
+
+var metastoreRules = []propertyRule{
+	{
+		Key:   "hive.metastore.authentication.type",
+		Value: "KERBEROS",
+		Requires: []string{
+			"hive.metastore.service.principal",
+			"hive.metastore.client.principal",
+			"hive.metastore.client.keytab",
+		},
+	},
+	{
+		Key:      "hive.hdfs.authentication.type",
+		Value:    "KERBEROS",
+		Requires: []string{"hive.hdfs.trino.principal", "hive.hdfs.trino.keytab"},
+	},
+	{Key: "hive.metastore", Value: "thrift", Requires: []string{"hive.metastore.uri"}},
+	{Key: "hive.metastore", Value: "glue", Requires: []string{"hive.metastore.glue.region"}},
+	{Key: "hive.metastore.glue.aws-access-key", Requires: []string{"hive.metastore.glue.aws-secret-key"}},
+	{
+		Key:      "hive.metastore.glue.use-web-identity-token-credentials-provider",
+		Value:    "true",
+		Excludes: []string{"hive.metastore.glue.aws-access-key"},
+	},
+	{Key: "s3.aws-access-key", Requires: []string{"s3.aws-secret-key"}},
+	{Key: "azure.auth-type", Value: "ACCESS_KEY", Requires: []string{"azure.access-key"}},
+	{
+		Key:   "azure.auth-type",
+		Value: "OAUTH",
+		Requires: []string{
+			"azure.oauth.tenant-id",
+			"azure.oauth.endpoint",
+			"azure.oauth.client-id",
+			"azure.oauth.secret",
+		},
+	},
+	{Key: "gcs.json-key", Excludes: []string{"gcs.json-key-file-path"}},
+}
+
+var hiveProperties = map[string]PropertySpec{
+	"hive.storage-format": enumProperty(
+		"ORC", "PARQUET", "AVRO", "RCBINARY", "RCTEXT", "SEQUENCEFILE", "JSON", "OPENX_JSON", "TEXTFILE", "CSV", "REGEX",
+	),
+	"hive.compression-codec":                            compressionCodecProperty,
+	"hive.security":                                     enumProperty("allow-all", "read-only", "file", "sql-standard", "system"),
+	"security.config-file":                              stringProperty,
+	"security.refresh-period":                           durationProperty,
+	"hive.recursive-directories":                        booleanProperty,
+	"hive.ignore-absent-partitions":                     booleanProperty,
+	"hive.non-managed-table-writes-enabled":             booleanProperty,
+	"hive.non-managed-table-creates-enabled":            booleanProperty,
+	"hive.collect-column-statistics-on-write":           booleanProperty,
+	"hive.max-partitions-per-scan":                      integerProperty,
+	"hive.max-partitions-per-writers":                   integerProperty,
+	"hive.max-partitions-for-eager-load":                integerProperty,
+	"hive.timestamp-precision":                          enumProperty("MILLISECONDS", "MICROSECONDS", "NANOSECONDS"),
+	"hive.partition-projection-enabled":                 booleanProperty,
+	"hive.auto-purge":                                   booleanProperty,
+	"hive.immutable-partitions":                         booleanProperty,
+	"hive.create-empty-bucket-files":                    booleanProperty,
+	"hive.respect-table-format":                         booleanProperty,
+	"hive.translate-hive-views":                         booleanProperty,
+	"hive.hive-views.enabled":                           booleanProperty,
+	"hive.hive-views.legacy-translation":                booleanProperty,
+	"hive.hive-views.run-as-invoker":                    booleanProperty,
+	"hive.query-partition-filter-required":              booleanProperty,
+	"hive.query-partition-filter-required-schemas":      stringProperty,
+	"hive.temporary-staging-directory-enabled":          booleanProperty,
+	"hive.temporary-staging-directory-path":             stringProperty,
+	"hive.file-status-cache-tables":                     stringProperty,
+	"hive.file-status-cache.max-retained-size":          dataSizeProperty,
+	"hive.file-status-cache-expire-time":                durationProperty,
+	"hive.per-transaction-metastore-cache-maximum-size": integerProperty,
+	"hive.max-initial-splits":                           integerProperty,
+	"hive.max-initial-split-size":                       dataSizeProperty,
+	"hive.max-split-size":                               dataSizeProperty,
+	"hive.max-outstanding-splits":                       integerProperty,
+	"hive.max-splits-per-second":                        integerProperty,
+	"hive.parquet.use-column-names":                     booleanProperty,
+	"hive.orc.use-column-names":                         booleanProperty,
+	"hive.target-max-file-size":                         dataSizeProperty,
+	"hive.iceberg-catalog-name":                         stringProperty,
+	"hive.delta-lake-catalog-name":                      stringProperty,
+	"hive.hudi-catalog-name":                            stringProperty,
+	"hive.dynamic-filtering.wait-timeout":               durationProperty,
+}
+
+var hiveRules = []propertyRule{
+	{Key: "hive.security", Value: "file", Requires: []string{"security.config-file"}},
+}
+
+var icebergProperties = map[string]PropertySpec{
+	"iceberg.catalog.type":                              enumProperty("hive_metastore", "glue", "jdbc", "rest", "nessie", "snowflake"),
+	"iceberg.file-format":                               enumProperty("ORC", "PARQUET", "AVRO"),
+	"iceberg.compression-codec":                         compressionCodecProperty,
+	"iceberg.security":                                  enumProperty("ALLOW_ALL", "READ_ONLY", "SYSTEM", "FILE"),
+	"security.config-file":                              stringProperty,
+	"security.refresh-period":                           durationProperty,
+	"iceberg.max-partitions-per-writer":                 integerProperty,
+	"iceberg.target-max-file-size":                      dataSizeProperty,
+	"iceberg.unique-table-location":                     booleanProperty,
+	"iceberg.dynamic-filtering.wait-timeout":            durationProperty,
+	"iceberg.table-statistics-enabled":                  booleanProperty,
+	"iceberg.extended-statistics.enabled":               booleanProperty,
+	"iceberg.projection-pushdown-enabled":               booleanProperty,
+	"iceberg.hive-catalog-name":                         stringProperty,
+	"iceberg.register-table-procedure.enabled":          booleanProperty,
+	"iceberg.query-partition-filter-required":           booleanProperty,
+	"iceberg.sorted-writing-enabled":                    booleanProperty,
+	"iceberg.expire-snapshots.min-retention":            durationProperty,
+	"iceberg.remove-orphan-files.min-retention":         durationProperty,
+	"iceberg.materialized-views.storage-schema":         stringProperty,
+	"iceberg.materialized-views.hide-storage-table":     booleanProperty,
+	"iceberg.delete-schema-locations-fallback":          booleanProperty,
+	"iceberg.minimum-assigned-split-weight":             stringProperty,
+	"iceberg.rest-catalog.uri":                          stringProperty,
+	"iceberg.rest-catalog.warehouse":                    stringProperty,
+	"iceberg.rest-catalog.security":                     enumProperty("NONE", "OAUTH2"),
+	"iceberg.rest-catalog.session":                      enumProperty("NONE", "USER"),
+	"iceberg.rest-catalog.oauth2.credential":            stringProperty,
+	"iceberg.rest-catalog.oauth2.token":                 stringProperty,
+	"iceberg.rest-catalog.oauth2.scope":                 stringProperty,
+	"iceberg.rest-catalog.oauth2.server-uri":            stringProperty,
+	"iceberg.rest-catalog.oauth2.token-refresh-enabled": booleanProperty,
+	"iceberg.jdbc-catalog.driver-class":                 stringProperty,
+	"iceberg.jdbc-catalog.connection-url":               stringProperty,
+	"iceberg.jdbc-catalog.connection-user":              stringProperty,
+	"iceberg.jdbc-catalog.connection-password":          stringProperty,
+	"iceberg.jdbc-catalog.catalog-name":                 stringProperty,
+	"iceberg.jdbc-catalog.default-warehouse-dir":        stringProperty,
+	"iceberg.nessie-catalog.uri":                        stringProperty,
+	"iceberg.nessie-catalog.ref":                        stringProperty,
+	"iceberg.nessie-catalog.default-warehouse-dir":      stringProperty,
+	"iceberg.nessie-catalog.authentication.type":        enumProperty("BEARER"),
+	"iceberg.nessie-catalog.authentication.token":       stringProperty,
+	"iceberg.nessie-catalog.read-timeout":               durationProperty,
+	"iceberg.nessie-catalog.connection-timeout":         durationProperty,
+	"iceberg.nessie-catalog.enable-compression":         booleanProperty,
+}
+
+var icebergRules = []propertyRule{
+	{Key: "iceberg.security", Value: "FILE", Requires: []string{"security.config-file"}},
+	{Key: "iceberg.catalog.type", Value: "hive_metastore", Requires: []string{"hive.metastore.uri"}},
+	{Key: "iceberg.catalog.type", Value: "glue", Requires: []string{"hive.metastore.glue.region"}},
+	{Key: "iceberg.catalog.type", Value: "rest", Requires: []string{"iceberg.rest-catalog.uri"}},
+	{
+		Key:   "iceberg.catalog.type",
+		Value: "jdbc",
+		Requires: []string{
+			"iceberg.jdbc-catalog.connection-url",
+			"iceberg.jdbc-catalog.catalog-name",
+			"iceberg.jdbc-catalog.default-warehouse-dir",
+		},
+	},
+	{
+		Key:      "iceberg.catalog.type",
+		Value:    "nessie",
+		Requires: []string{"iceberg.nessie-catalog.uri", "iceberg.nessie-catalog.default-warehouse-dir"},
+	},
+	{Key: "iceberg.nessie-catalog.authentication.type", Value: "BEARER", Requires: []string{"iceberg.nessie-catalog.authentication.token"}},
+	{Key: "iceberg.rest-catalog.oauth2.credential", Excludes: []string{"iceberg.rest-catalog.oauth2.token"}},
+}
+
+var deltaLakeProperties = map[string]PropertySpec{
+	"delta.register-table-procedure.enabled":     booleanProperty,
+	"delta.vacuum.min-retention":                 durationProperty,
+	"delta.enable-non-concurrent-writes":         booleanProperty,
+	"delta.default-checkpoint-writing-interval":  integerProperty,
+	"delta.hive-catalog-name":                    stringProperty,
+	"delta.checkpoint-filtering.enabled":         booleanProperty,
+	"delta.dynamic-filtering.wait-timeout":       durationProperty,
+	"delta.table-statistics-enabled":             booleanProperty,
+	"delta.extended-statistics.enabled":          booleanProperty,
+	"delta.extended-statistics.collect-on-write": booleanProperty,
+	"delta.compression-codec":                    compressionCodecProperty,
+	"delta.max-partitions-per-writer":            integerProperty,
+	"delta.target-max-file-size":                 dataSizeProperty,
+	"delta.unique-table-location":                booleanProperty,
+	"delta.metadata.cache-ttl":                   durationProperty,
+	"delta.metadata.cache-max-retained-size":     dataSizeProperty,
+	"delta.metadata.live-files.cache-ttl":        durationProperty,
+	"delta.projection-pushdown-enabled":          booleanProperty,
+	"delta.query-partition-filter-required":      booleanProperty,
+	"delta.security":                             enumProperty("ALLOW_ALL", "READ_ONLY", "SYSTEM", "FILE"),
+	"security.config-file":                       stringProperty,
+	"security.refresh-period":                    durationProperty,
+}
+
+var deltaLakeRules = []propertyRule{
+	{Key: "delta.security", Value: "FILE", Requires: []string{"security.config-file"}},
+}
+
+var hudiProperties = map[string]PropertySpec{
+	"hudi.columns-to-hide":                  stringProperty,
+	"hudi.parquet.use-column-names":         booleanProperty,
+	"hudi.metadata-enabled":                 booleanProperty,
+	"hudi.split-loader-parallelism":         integerProperty,
+	"hudi.split-generator-parallelism":      integerProperty,
+	"hudi.min-partition-batch-size":         integerProperty,
+	"hudi.max-partition-batch-size":         integerProperty,
+	"hudi.size-based-split-weights-enabled": booleanProperty,
+	"hudi.standard-split-weight-size":       dataSizeProperty,
+	"hudi.minimum-assigned-split-weight":    stringProperty,
+	"hudi.max-splits-per-second":            integerProperty,
+	"hudi.max-outstanding-splits":           integerProperty,
+	"hudi.query-partition-filter-required":  booleanProperty,
+	"hudi.ignore-absent-partitions":         booleanProperty,
+}
+
+var mongodbProperties = map[string]PropertySpec{
+	"mongodb.connection-url":                 stringProperty,
+	"mongodb.schema-collection":              stringProperty,
+	"mongodb.case-insensitive-name-matching": booleanProperty,
+	"mongodb.min-connections-per-host":       integerProperty,
+	"mongodb.connections-per-host":           integerProperty,
+	"mongodb.max-wait-time":                  integerProperty,
+	"mongodb.max-connection-idle-time":       integerProperty,
+	"mongodb.connection-timeout":             integerProperty,
+	"mongodb.socket-timeout":                 integerProperty,
+	"mongodb.tls.enabled":                    booleanProperty,
+	"mongodb.tls.keystore-path":              stringProperty,
+	"mongodb.tls.keystore-password":          stringProperty,
+	"mongodb.tls.truststore-path":            stringProperty,
+	"mongodb.tls.truststore-password":        stringProperty,
+	"mongodb.read-preference":                enumProperty("PRIMARY", "PRIMARY_PREFERRED", "SECONDARY", "SECONDARY_PREFERRED", "NEAREST"),
+	"mongodb.write-concern":                  enumProperty("ACKNOWLEDGED", "JOURNALED", "MAJORITY", "UNACKNOWLEDGED", "W1", "W2", "W3"),
+	"mongodb.required-replica-set":           stringProperty,
+	"mongodb.cursor-batch-size":              integerProperty,
+	"mongodb.allow-local-scheduling":         booleanProperty,
+	"mongodb.dynamic-filtering.wait-timeout": durationProperty,
+}
+
+var cassandraProperties = map[string]PropertySpec{
+	"cassandra.contact-points":                  stringProperty,
+	"cassandra.native-protocol-port":            integerProperty,
+	"cassandra.load-policy.dc-aware.local-dc":   stringProperty,
+	"cassandra.load-policy.use-dc-aware":        booleanProperty,
+	"cassandra.username":                        stringProperty,
+	"cassandra.password":                        stringProperty,
+	"cassandra.consistency-level":               enumProperty("ANY", "ONE", "TWO", "THREE", "QUORUM", "ALL", "LOCAL_QUORUM", "EACH_QUORUM", "SERIAL", "LOCAL_SERIAL", "LOCAL_ONE"),
+	"cassandra.protocol-version":                enumProperty("V3", "V4", "V5", "V6"),
+	"cassandra.allow-drop-table":                booleanProperty,
+	"cassandra.security":                        enumProperty("NONE", "PASSWORD"),
+	"cassandra.fetch-size":                      integerProperty,
+	"cassandra.partition-size-for-batch-select": integerProperty,
+	"cassandra.split-size":                      integerProperty,
+	"cassandra.splits-per-node":                 integerProperty,
+	"cassandra.batch-size":                      integerProperty,
+	"cassandra.client.read-timeout":             durationProperty,
+	"cassandra.client.connect-timeout":          durationProperty,
+	"cassandra.retry-policy":                    enumProperty("DEFAULT", "BACKOFF", "DOWNGRADING_CONSISTENCY", "FALLTHROUGH"),
+	"cassandra.speculative-execution.limit":     integerProperty,
+	"cassandra.speculative-execution.delay":     durationProperty,
+	"cassandra.tls.enabled":                     booleanProperty,
+	"cassandra.tls.keystore-path":               stringProperty,
+	"cassandra.tls.keystore-password":           stringProperty,
+	"cassandra.tls.truststore-path":             stringProperty,
+	"cassandra.tls.truststore-password":         stringProperty,
+}
+
+var cassandraRules = []propertyRule{
+	{Key: "cassandra.security", Value: "PASSWORD", Requires: []string{"cassandra.username", "cassandra.password"}},
+	{Key: "cassandra.username", Requires: []string{"cassandra.password"}},
+}
+
+var redisProperties = map[string]PropertySpec{
+	"redis.nodes":                       stringProperty,
+	"redis.default-schema":              stringProperty,
+	"redis.table-names":                 stringProperty,
+	"redis.table-description-dir":       stringProperty,
+	"redis.table-description-cache-ttl": durationProperty,
+	"redis.key-prefix-schema-table":     booleanProperty,
+	"redis.key-delimiter":               stringProperty,
+	"redis.scan-count":                  integerProperty,
+	"redis.max-keys-per-fetch":          integerProperty,
+	"redis.database-index":              integerProperty,
+	"redis.user":                        stringProperty,
+	"redis.password":                    stringProperty,
+	"redis.hide-internal-columns":       booleanProperty,
+}
+
+var jdbcProperties = map[string]PropertySpec{
+	"connection-url":                                            stringProperty,
+	"connection-user":                                           stringProperty,
+	"connection-password":                                       stringProperty,
+	"case-insensitive-name-matching":                            booleanProperty,
+	"case-insensitive-name-matching.cache-ttl":                  durationProperty,
+	"case-insensitive-name-matching.config-file":                stringProperty,
+	"case-insensitive-name-matching.config-file.refresh-period": durationProperty,
+	"metadata.cache-ttl":                                        durationProperty,
+	"metadata.cache-missing":                                    booleanProperty,
+	"metadata.cache-maximum-size":                               integerProperty,
+	"metadata.schemas.cache-ttl":                                durationProperty,
+	"metadata.tables.cache-ttl":                                 durationProperty,
+	"metadata.statistics.cache-ttl":                             durationProperty,
+	"write.batch-size":                                          integerProperty,
+	"insert.non-transactional-insert.enabled":                   booleanProperty,
+	"join-pushdown.enabled":                                     booleanProperty,
+	"join-pushdown.strategy":                                    enumProperty("AUTOMATIC", "EAGER"),
+	"domain-compaction-threshold":                               integerProperty,
+	"unsupported-type-handling":                                 enumProperty("IGNORE", "CONVERT_TO_VARCHAR"),
+	"jdbc-types-mapped-to-varchar":                              stringProperty,
+	"query.comment-format":                                      stringProperty,
+	"dynamic-filtering.enabled":                                 booleanProperty,
+	"dynamic-filtering.wait-timeout":                            durationProperty,
+	"statistics.enabled":                                        booleanProperty,
+	"decimal-mapping":                                           enumProperty("STRICT", "ALLOW_OVERFLOW"),
+	"decimal-rounding-mode": enumProperty(
+		"UNNECESSARY", "CEILING", "FLOOR", "HALF_DOWN", "HALF_EVEN", "HALF_UP", "UP", "DOWN",
+	),
+	"decimal-default-scale": integerProperty,
+}
+
+var jdbcRules = []propertyRule{
+	{Key: "connection-password", Requires: []string{"connection-url"}},
+}
+
+var searchProperties = map[string]PropertySpec{
+	"host":                    stringProperty,
+	"port":                    integerProperty,
+	"default-schema-name":     stringProperty,
+	"scroll-size":             integerProperty,
+	"scroll-timeout":          durationProperty,
+	"request-timeout":         durationProperty,
+	"connect-timeout":         durationProperty,
+	"backoff-init-delay":      durationProperty,
+	"backoff-max-delay":       durationProperty,
+	"max-retry-time":          durationProperty,
+	"node-refresh-interval":   durationProperty,
+	"ignore-publish-address":  booleanProperty,
+	"http-thread-count":       integerProperty,
+	"max-http-connections":    integerProperty,
+	"security":                enumProperty("AWS", "PASSWORD"),
+	"auth.user":               stringProperty,
+	"auth.password":           stringProperty,
+	"aws.region":              stringProperty,
+	"aws.access-key":          stringProperty,
+	"aws.secret-key":          stringProperty,
+	"aws.iam-role":            stringProperty,
+	"aws.external-id":         stringProperty,
+	"tls.enabled":             booleanProperty,
+	"tls.keystore-path":       stringProperty,
+	"tls.keystore-password":   stringProperty,
+	"tls.truststore-path":     stringProperty,
+	"tls.truststore-password": stringProperty,
+	"tls.verify-hostnames":    booleanProperty,
+}
+
+// newSearchSchema prefixes the search connector properties with the connector name.
+func newSearchSchema(connectorName string) connectorSchema {
+	prefix := connectorName + "."
+	props := make(map[string]PropertySpec, len(searchProperties))
+	for key, spec := range searchProperties {
+		props[prefix+key] = spec
+	}
+	return newConnectorSchema([]propertyRule{
+		{Key: prefix + "security", Value: "PASSWORD", Requires: []string{prefix + "auth.user", prefix + "auth.password"}},
+		{Key: prefix + "security", Value: "AWS", Requires: []string{prefix + "aws.region"}},
+	}, props)
+}
+
+var kafkaProperties = map[string]PropertySpec{
+	"kafka.nodes":                                         stringProperty,
+	"kafka.default-schema":                                stringProperty,
+	"kafka.table-names":                                   stringProperty,
+	"kafka.hide-internal-columns":                         booleanProperty,
+	"kafka.internal-column-prefix":                        stringProperty,
+	"kafka.buffer-size":                                   dataSizeProperty,
+	"kafka.messages-per-split":                            integerProperty,
+	"kafka.timestamp-upper-bound-force-push-down-enabled": booleanProperty,
+	"kafka.security-protocol":                             enumProperty("PLAINTEXT", "SSL", "SASL_PLAINTEXT", "SASL_SSL"),
+	"kafka.ssl.keystore.location":                         stringProperty,
+	"kafka.ssl.keystore.password":                         stringProperty,
+	"kafka.ssl.keystore.type":                             enumProperty("JKS", "PKCS12"),
+	"kafka.ssl.truststore.location":                       stringProperty,
+	"kafka.ssl.truststore.password":                       stringProperty,
+	"kafka.ssl.truststore.type":                           enumProperty("JKS", "PKCS12"),
+	"kafka.ssl.key.password":                              stringProperty,
+	"kafka.ssl.endpoint-identification-algorithm":         enumProperty("https", "disabled"),
+	"kafka.config.resources":                              stringProperty,
+	"kafka.table-description-supplier":                    enumProperty("FILE", "CONFLUENT"),
+	"kafka.table-description-dir":                         stringProperty,
+	"kafka.confluent-schema-registry-url":                 stringProperty,
+	"kafka.confluent-schema-registry-client-cache-size":   integerProperty,
+	"kafka.confluent-subjects-cache-refresh-interval":     durationProperty,
+	"kafka.confluent-schema-registry-auth-type":           enumProperty("NONE", "BASIC_AUTH"),
+	"kafka.confluent-schema-registry.basic-auth.username": stringProperty,
+	"kafka.confluent-schema-registry.basic-auth.password": stringProperty,
+	"kafka.empty-field-strategy":                          enumProperty("IGNORE", "FAIL", "MARK"),
+}
+
+var kafkaRules = []propertyRule{
+	{Key: "kafka.table-description-supplier", Value: "CONFLUENT", Requires: []string{"kafka.confluent-schema-registry-url"}},
+	{Key: "kafka.table-description-supplier", Value: "FILE", Excludes: []string{"kafka.confluent-schema-registry-url"}},
+}
+
+// connectorSchemas are the known properties of the built-in connectors of the default product version,
+// see schemaChanges for the later versions.
+// The properties and their types are taken from the configuration property tables of the connector documentation
+// of the default product version, https://trino.io/docs/451/connector.html, they are unchanged up to 455,
+// the latest product version of the e2e tests.
+// When a product version is added to the e2e tests, the release notes up to it, https://trino.io/docs/current/release.html,
+// are checked for added, renamed and removed properties of these connectors, which are recorded as a schemaChange.
+// When the default product version is raised, the changes up to it are merged into the table.
+// A table lagging behind trino only misses warnings: unknown properties are warnings, only values of the wrong type
+// and conflicting properties are errors.
+// Connectors not in the table, e.g. a plugin installed in a custom image, are not validated.
+var connectorSchemas = map[string]connectorSchema{
+	"hive": newConnectorSchema(
+		slices.Concat(metastoreRules, hiveRules),
+		hiveProperties, metastoreProperties, fileSystemProperties, legacyFileSystemProperties,
+	),
+	"iceberg": newConnectorSchema(
+		slices.Concat(metastoreRules, icebergRules),
+		icebergProperties, metastoreProperties, fileSystemProperties, legacyFileSystemProperties,
+	),
+	"delta_lake": newConnectorSchema(
+		slices.Concat(metastoreRules, deltaLakeRules),
+		deltaLakeProperties, metastoreProperties, fileSystemProperties, legacyFileSystemProperties,
+	),
+	"postgresql": newConnectorSchema(jdbcRules, jdbcProperties, map[string]PropertySpec{
+		"postgresql.array-mapping":                                    enumProperty("DISABLED", "AS_ARRAY", "AS_JSON"),
+		"postgresql.include-system-tables":                            booleanProperty,
+		"postgresql.experimental.enable-string-pushdown-with-collate": booleanProperty,
+	}),
+	"mysql": newConnectorSchema(jdbcRules, jdbcProperties, map[string]PropertySpec{
+		"mysql.auto-reconnect":     booleanProperty,
+		"mysql.max-reconnects":     integerProperty,
+		"mysql.connection-timeout": durationProperty,
+	}),
+	"mariadb": newConnectorSchema(jdbcRules, jdbcProperties),
+	"sqlserver": newConnectorSchema(jdbcRules, jdbcProperties, map[string]PropertySpec{
+		"sqlserver.snapshot-isolation.disabled":                booleanProperty,
+		"sqlserver.bulk-copy-for-write.enabled":                booleanProperty,
+		"sqlserver.bulk-copy-for-write.lock-destination-table": booleanProperty,
+		"sqlserver.stored-procedure-table-function-enabled":    booleanProperty,
+	}),
+	"kafka":         newConnectorSchema(kafkaRules, kafkaProperties),
+	"elasticsearch": newSearchSchema("elasticsearch"),
+	"opensearch":    newSearchSchema("opensearch"),
+	"tpch": newConnectorSchema(nil, map[string]PropertySpec{
+		"tpch.splits-per-node":                integerProperty,
+		"tpch.column-naming":                  enumProperty("SIMPLIFIED", "STANDARD"),
+		"tpch.double-type-mapping":            enumProperty("DOUBLE", "DECIMAL"),
+		"tpch.produce-pages":                  booleanProperty,
+		"tpch.max-rows-per-page":              integerProperty,
+		"tpch.table-scan-redirection-catalog": stringProperty,
+		"tpch.table-scan-redirection-schema":  stringProperty,
+	}),
+	"hudi": newConnectorSchema(metastoreRules, hudiProperties, metastoreProperties, fileSystemProperties, legacyFileSystemProperties),
+	"memory": newConnectorSchema(nil, map[string]PropertySpec{
+		"memory.max-data-per-node": dataSizeProperty,
+	}),
+	"blackhole": newConnectorSchema(nil),
+	"mongodb":   newConnectorSchema(nil, mongodbProperties),
+	"cassandra": newConnectorSchema(cassandraRules, cassandraProperties),
+	"redis":     newConnectorSchema(nil, redisProperties),
+	"tpcds": newConnectorSchema(nil, map[string]PropertySpec{
+		"tpcds.splits-per-node": integerProperty,
+		"tpcds.split-count":     integerProperty,
+		"tpcds.with-no-sexism":  booleanProperty,
+	}),
+}
+
+// schemaChange is a change of the known properties of connectors in a trino version.
+type schemaChange struct {
+	Version    int
+	Connectors []string
+	Added      map[string]PropertySpec
+	Removed    []string
+}
+
+// schemaChanges are the changes of the known properties in the trino versions after the default product version,
+// ordered by version. A cluster of a version is validated against connectorSchemas with the changes up to it.
+var schemaChanges = []schemaChange{
+	{
+		// The legacy file systems are removed, only the native file systems remain.
+		Version:    470,
+		Connectors: []string{"hive", "iceberg", "delta_lake", "hudi"},
+		Removed:    slices.Collect(maps.Keys(legacyFileSystemProperties)),
+	},
+}
+
+// getConnectorSchema returns the known properties of a connector in the product version.
+// A version which is not a number, e.g. a custom build, is validated as the default product version.
+func getConnectorSchema(connectorName string, productVersion string) (connectorSchema, bool) {
+	schema, ok := connectorSchemas[connectorName]
+	if !ok {
+		return schema, false
+	}
+	version, err := strconv.Atoi(strings.SplitN(productVersion, "-", 2)[0])
+	if err != nil {
+		return schema, true
+	}
+
+	for _, change := range schemaChanges {
+		if change.Version > version || !slices.Contains(change.Connectors, connectorName) {
+			continue
+		}
+		changed := connectorSchema{properties: maps.Clone(schema.properties), rules: schema.rules}
+		for _, key := range change.Removed {
+			delete(changed.properties, key)
+		}
+		maps.Copy(changed.properties, change.Added)
+		schema = changed
+	}
+	return schema, true
+}
+
+// ValidationError is returned when the properties of a catalog are invalid,
+// e.g. a value of the wrong type or conflicting properties.
+type ValidationError struct {
+	Catalog string
+	Errors  []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("catalog %s has invalid properties: %s", e.Catalog, strings.Join(e.Errors, "; "))
+}
+
+// ValidateProperties validates the properties of a catalog against the known properties of its connector
+// in the default product version. A TrinoCatalog is shared by clusters of any version, so it is validated
+// against the default product version.
+func ValidateProperties(catalogName string, p *properties.Properties) ([]string, error) {
+	return ValidateVersionProperties(catalogName, trinov1alpha1.DefaultProductVersion, p)
+}
+
+// ValidateVersionProperties validates the properties of a catalog against the known properties of its connector
+// in the product version. Unknown properties are returned as warnings, as the table may lag behind trino,
+// values of the wrong type and conflicting properties are returned as a *ValidationError.
+// Values referencing an environment variable or a file are not type checked.
+// The properties of all connectors are checked to be rendered as is, i.e. names without whitespaces, '=' or ':'
+// and values without line breaks.
+func ValidateVersionProperties(catalogName string, productVersion string, p *properties.Properties) ([]string, error) {
+	connectorName, ok := p.Get("connector.name")
+	if !ok || connectorName == "" {
+		return nil, &ValidationError{Catalog: catalogName, Errors: []string{"connector.name is required"}}
+	}
+
+	keys := slices.Clone(p.Keys())
+	sort.Strings(keys)
+
+	errs := make([]string, 0)
+	for _, key := range keys {
+		value, _ := p.Get(key)
+		if !propertyNameRegex.MatchString(key) {
+			errs = append(errs, fmt.Sprintf("property name %q must not contain whitespaces, '=' or ':'", key))
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			errs = append(errs, fmt.Sprintf("%s must not contain line breaks", key))
+		}
+	}
+
+	schema, ok := getConnectorSchema(connectorName, productVersion)
+	if !ok {
+		if len(errs) > 0 {
+			return nil, &ValidationError{Catalog: catalogName, Errors: errs}
+		}
+		return nil, nil
+	}
+
+	warnings := make([]string, 0)
+	for _, key := range keys {
+		if key == "connector.name" {
+			continue
+		}
+		spec, ok := schema.properties[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown property %s of connector %s", key, connectorName))
+			continue
+		}
+		value, _ := p.Get(key)
+		if strings.Contains(value, "${") {
+			continue
+		}
+		if reason := spec.validate(value); reason != "" {
+			errs = append(errs, fmt.Sprintf("%s=%s %s", key, value, reason))
+		}
+	}
+
+	for _, rule := range schema.rules {
+		if !rule.matches(p) {
+			continue
+		}
+		for _, key := range rule.Requires {
+			if _, ok := p.Get(key); !ok {
+				errs = append(errs, fmt.Sprintf("%s is required when %s", key, rule.condition()))
+			}
+		}
+		for _, key := range rule.Excludes {
+			if _, ok := p.Get(key); ok {
+				errs = append(errs, fmt.Sprintf("%s conflicts with %s", key, rule.condition()))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, &ValidationError{Catalog: catalogName, Errors: errs}
+	}
+	return warnings, nil
+}
+
+// ValidateCatalogProperties validates the legacy catalog properties of a cluster of the product version,
+// keyed by catalog name. The warnings are prefixed with the catalog name, the errors of all catalogs are joined.
+func ValidateCatalogProperties(productVersion string, catalogProperties map[string]map[string]string) ([]string, error) {
+	names := make([]string, 0, len(catalogProperties))
+	for name := range catalogProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	warnings := make([]string, 0)
+	errs := make([]error, 0)
+	for _, name := range names {
+		p := properties.NewPropertiesFromMap(catalogProperties[name])
+		catalogWarnings, err := ValidateVersionProperties(name, productVersion, p)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, warning := range catalogWarnings {
+			warnings = append(warnings, name+": "+warning)
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
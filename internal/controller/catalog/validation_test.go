@@ -0,0 +1,62 @@
+package catalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+)
+
+func TestValidateVersionProperties(t *testing.T) {
+	tests := map[string]struct {
+		productVersion string
+		warnings       int
+	}{
+		"default":   {productVersion: "451", warnings: 0},
+		"custom":    {productVersion: "custom", warnings: 0},
+		"suffixed":  {productVersion: "470-1", warnings: 1},
+		"removed":   {productVersion: "470", warnings: 1},
+		"unchanged": {productVersion: "469", warnings: 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := properties.NewPropertiesFromMap(map[string]string{
+				"connector.name":         "hive",
+				"hive.metastore.uri":     "thrift://metastore:9083",
+				"hive.s3.aws-access-key": "${ENV:S3_ACCESS_KEY}",
+			})
+			warnings, err := ValidateVersionProperties("lake", tt.productVersion, p)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(warnings).To(HaveLen(tt.warnings))
+		})
+	}
+}
+
+func TestValidateCatalogPropertiesJoinsErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := ValidateCatalogProperties("451", map[string]map[string]string{
+		"a": {"connector.name": "hive", "hive.metastore.uri": "thrift://metastore:9083", "hive.s3.ssl.enabled": "maybe"},
+		"b": {},
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("catalog a has invalid properties"))
+	g.Expect(err.Error()).To(ContainSubstring("catalog b has invalid properties: connector.name is required"))
+}
+
+func TestValidatePropertiesRenderedAsIs(t *testing.T) {
+	g := NewWithT(t)
+
+	// The connector is not in the table, the properties are still checked to be rendered as is.
+	p := properties.NewPropertiesFromMap(map[string]string{
+		"connector.name":  "custom",
+		"custom.url":      "http://custom:8080\nconnector.name=memory",
+		"custom url":      "x",
+		"custom.enabled":  "true",
+		"custom.user=foo": "bar",
+	})
+	_, err := ValidateVersionProperties("custom", "451", p)
+	g.Expect(err).To(MatchError(`catalog custom has invalid properties: property name "custom url" must not contain whitespaces, '=' or ':'; ` +
+		`custom.url must not contain line breaks; property name "custom.user=foo" must not contain whitespaces, '=' or ':'`))
+}
@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
 	"github.com/zncdatadev/trino-operator/internal/controller/common"
 	"github.com/zncdatadev/trino-operator/internal/controller/coordinator"
 	"github.com/zncdatadev/trino-operator/internal/controller/worker"
@@ -23,16 +24,20 @@ type Reconciler struct {
 	reconciler.BaseCluster[*trinov1alpha1.TrinoClusterSpec]
 
 	ClusterConfig *trinov1alpha1.ClusterConfigSpec
+	// Catalogs are the catalogs resolved once per reconcile, shared by the resources of all role groups.
+	Catalogs *catalog.TrinoCatalogs
 }
 
 func NewClusterReconciler(
 	client *client.Client,
 	clusterInfo reconciler.ClusterInfo,
 	spec *trinov1alpha1.TrinoClusterSpec,
+	catalogs *catalog.TrinoCatalogs,
 ) *Reconciler {
 	return &Reconciler{
 		BaseCluster:   *reconciler.NewBaseCluster(client, clusterInfo, spec.ClusterOperation, spec),
 		ClusterConfig: spec.ClusterConfig,
+		Catalogs:      catalogs,
 	}
 }
 
@@ -88,6 +93,7 @@ func (r *Reconciler) RegisterResources(ctx context.Context) error {
 		r.Client,
 		r.IsStopped(),
 		r.ClusterConfig,
+		r.Catalogs,
 		coordinatorRoleInfo,
 		r.GetImage(),
 		coordinatorSvcFqdn,
@@ -102,6 +108,7 @@ func (r *Reconciler) RegisterResources(ctx context.Context) error {
 		r.Client,
 		r.IsStopped(),
 		r.ClusterConfig,
+		r.Catalogs,
 		reconciler.RoleInfo{ClusterInfo: r.ClusterInfo, RoleName: string(common.RoleWorker)},
 		r.GetImage(),
 		coordinatorSvcFqdn,
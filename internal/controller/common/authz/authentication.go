@@ -2,7 +2,11 @@ package authz
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"strings"
 
 	authv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/authentication/v1alpha1"
 	"github.com/zncdatadev/operator-go/pkg/client"
@@ -30,13 +34,31 @@ type Authenticator interface {
 	GetCommands() []string
 }
 
-func AuthenticatorFectory(config *trinov1alpha1.OidcSpec, provider *authv1alpha1.AuthenticationProvider) (AuthenticationType, Authenticator) {
+var invalidVolumeNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// getVolumeName returns a volume name scoped to the AuthenticationClass, e.g. ldap-tls-ca-openldap,
+// so the volumes of different AuthenticationClasses never conflict.
+// Volume names must be a DNS label, so long names are truncated with a hash suffix.
+func getVolumeName(name string, authenticationClassName string) string {
+	volumeName := strings.Trim(invalidVolumeNameRegex.ReplaceAllString(strings.ToLower(name+"-"+authenticationClassName), "-"), "-")
+	if len(volumeName) <= 63 {
+		return volumeName
+	}
+	hash := sha256.Sum256([]byte(volumeName))
+	return strings.TrimRight(volumeName[:54], "-") + "-" + hex.EncodeToString(hash[:])[:8]
+}
+
+func AuthenticatorFectory(
+	authenticationClassName string,
+	config *trinov1alpha1.OidcSpec,
+	provider *authv1alpha1.AuthenticationProvider,
+) (AuthenticationType, Authenticator) {
 	if provider.OIDC != nil {
-		return AuthenticationTypeOIDC, &Oidc{Config: config, Provider: provider.OIDC}
+		return AuthenticationTypeOIDC, &Oidc{AuthenticationClassName: authenticationClassName, Config: config, Provider: provider.OIDC}
 	} else if provider.Static != nil {
-		return AuthenticationTypeStatic, &Static{Provider: provider.Static}
+		return AuthenticationTypeStatic, &Static{AuthenticationClassName: authenticationClassName, Provider: provider.Static}
 	} else if provider.LDAP != nil {
-		return AuthenticationTypeLDAP, &Ldap{Provider: provider.LDAP}
+		return AuthenticationTypeLDAP, &Ldap{AuthenticationClassName: authenticationClassName, Provider: provider.LDAP}
 	} else {
 		return "", nil
 	}
@@ -66,7 +88,7 @@ func NewAuthentication(
 			return nil, err
 		}
 
-		authType, authenticator := AuthenticatorFectory(authenticationSpec.Oidc, obj.Spec.AuthenticationProvider)
+		authType, authenticator := AuthenticatorFectory(name, authenticationSpec.Oidc, obj.Spec.AuthenticationProvider)
 
 		if authenticator != nil {
 			if _, ok := authenticators[authType]; ok {
@@ -13,6 +13,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
 )
 
 var _ Authenticator = &Ldap{}
@@ -37,7 +39,27 @@ export ` + passwordEnvName + `=$(cat ` + passwordFile + `)
 set -x
 `
 
-	return []string{util.IndentTab4Spaces(s)}
+	commands := []string{util.IndentTab4Spaces(s)}
+	if caCert := l.getCaCert(); caCert != nil {
+		commands = append(commands, caCert.GetCommand())
+	}
+	return commands
+}
+
+// getCaCert returns the server CA of the ldap server, which is imported to the client truststore.
+func (l *Ldap) getCaCert() *truststore.CaCert {
+	if l.Provider.TLS == nil {
+		return nil
+	}
+	secretClass := truststore.GetCaSecretClass(l.Provider.TLS.Verification)
+	if secretClass == "" {
+		return nil
+	}
+	return truststore.NewCaCert(
+		getVolumeName("ldap-tls-ca", l.AuthenticationClassName),
+		secretClass,
+		path.Join(constants.KubedoopTlsDir, "ldap-ca", l.AuthenticationClassName),
+	)
 }
 
 func (l *Ldap) getBindCredentialsMountPath() string {
@@ -75,8 +97,6 @@ func (l *Ldap) GetConfigProperties() *properties.Properties {
 	p.Add("ldap.bind-dn", "${ENV:LDAP_USER}")
 	p.Add("ldap.bind-password", "${ENV:LDAP_PASSWORD}")
 
-	// TODO: Add ldap tls support
-
 	return p
 }
 
@@ -87,17 +107,21 @@ func (l *Ldap) GetEnvVars() []corev1.EnvVar {
 }
 
 func (l *Ldap) getBindCredentialsVolumeName() string {
-	return "ldap-bind-credentials"
+	return getVolumeName("ldap-bind-credentials", l.AuthenticationClassName)
 }
 
 // GetVolumeMounts implements Authenticator.
 func (l *Ldap) GetVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      l.getBindCredentialsVolumeName(),
 			MountPath: l.getBindCredentialsMountPath(),
 		},
 	}
+	if caCert := l.getCaCert(); caCert != nil {
+		volumeMounts = append(volumeMounts, caCert.GetVolumeMount())
+	}
+	return volumeMounts
 }
 
 // GetVolumes implements Authenticator.
@@ -144,5 +168,9 @@ func (l *Ldap) GetVolumes() []corev1.Volume {
 		},
 	}
 
-	return []corev1.Volume{secretVolume}
+	volumes := []corev1.Volume{secretVolume}
+	if caCert := l.getCaCert(); caCert != nil {
+		volumes = append(volumes, caCert.GetVolume())
+	}
+	return volumes
 }
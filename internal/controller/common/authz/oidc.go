@@ -2,14 +2,17 @@ package authz
 
 import (
 	"net/url"
+	"path"
 	"strconv"
 	"strings"
 
 	authv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/authentication/v1alpha1"
 	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	"github.com/zncdatadev/operator-go/pkg/constants"
 	corev1 "k8s.io/api/core/v1"
 
 	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
 )
 
 var _ Authenticator = &Oidc{}
@@ -34,8 +37,12 @@ func (o *Oidc) GetConfigProperties() *properties.Properties {
 	scopes[0] = "openid"
 	scopes[1] = "email"
 	scopes[2] = "profile"
+	scheme := "http"
+	if o.Provider.TLS != nil {
+		scheme = "https"
+	}
 	issuer := url.URL{
-		Scheme: "http",
+		Scheme: scheme,
 		Host:   o.Provider.Hostname,
 		Path:   o.Provider.RootPath,
 	}
@@ -85,14 +92,39 @@ func (o *Oidc) GetEnvVars() []corev1.EnvVar {
 	return envVars
 }
 
+// getCaCert returns the server CA of the oidc provider, which is imported to the client truststore.
+func (o *Oidc) getCaCert() *truststore.CaCert {
+	if o.Provider.TLS == nil {
+		return nil
+	}
+	secretClass := truststore.GetCaSecretClass(o.Provider.TLS.Verification)
+	if secretClass == "" {
+		return nil
+	}
+	return truststore.NewCaCert(
+		getVolumeName("oidc-tls-ca", o.AuthenticationClassName),
+		secretClass,
+		path.Join(constants.KubedoopTlsDir, "oidc-ca", o.AuthenticationClassName),
+	)
+}
+
 func (o *Oidc) GetCommands() []string {
+	if caCert := o.getCaCert(); caCert != nil {
+		return []string{caCert.GetCommand()}
+	}
 	return nil
 }
 
 func (o *Oidc) GetVolumes() []corev1.Volume {
+	if caCert := o.getCaCert(); caCert != nil {
+		return []corev1.Volume{caCert.GetVolume()}
+	}
 	return nil
 }
 
 func (o *Oidc) GetVolumeMounts() []corev1.VolumeMount {
+	if caCert := o.getCaCert(); caCert != nil {
+		return []corev1.VolumeMount{caCert.GetVolumeMount()}
+	}
 	return nil
 }
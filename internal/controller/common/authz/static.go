@@ -33,7 +33,7 @@ func (s *Static) getCredentialsMountPath() string {
 }
 
 func (s *Static) getVolumeName() string {
-	return "auth-secrets-" + s.AuthenticationClassName
+	return getVolumeName("auth-secrets", s.AuthenticationClassName)
 }
 
 func (s *Static) GetVolumeMounts() []corev1.VolumeMount {
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/zncdatadev/operator-go/pkg/builder"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	"github.com/zncdatadev/operator-go/pkg/reconciler"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinosv1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+var (
+	// ConfigSecretMountDir is where the config secret is mounted, each sensitive property is a file in it.
+	ConfigSecretMountDir = path.Join(constants.KubedoopRoot, "config-secret")
+
+	invalidSecretKeyRegex = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+)
+
+// isSensitiveProperty tells whether the property should be rendered to the config secret.
+func isSensitiveProperty(key string, value string) bool {
+	return catalog.IsSensitiveProperty(key, value)
+}
+
+// getConfigSecretKey returns the config secret key of a sensitive property, e.g. catalog-mysql.properties.connection-password
+func getConfigSecretKey(fileName string, key string) string {
+	return invalidSecretKeyRegex.ReplaceAllString(fileName+"."+key, "_")
+}
+
+// splitSensitiveProperties splits the sensitive properties of a properties file.
+// It returns the properties with sensitive values replaced by a file reference to the mounted config secret,
+// and the sensitive values keyed by config secret key.
+func splitSensitiveProperties(fileName string, p *properties.Properties) (*properties.Properties, map[string]string) {
+	configProperties := properties.NewProperties()
+	secretData := make(map[string]string)
+	for _, key := range p.Keys() {
+		value, _ := p.Get(key)
+		if !isSensitiveProperty(key, value) {
+			configProperties.Add(key, value)
+			continue
+		}
+		secretKey := getConfigSecretKey(fileName, key)
+		secretData[secretKey] = value
+		configProperties.Add(key, fmt.Sprintf("${FILE:%s}", path.Join(ConfigSecretMountDir, secretKey)))
+	}
+	return configProperties, secretData
+}
+
+func NewConfigSecretReconciler(
+	client *client.Client,
+	coordiantorSvcFqdn string,
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+	trinoConfig *trinosv1alpha1.ConfigSpec,
+	info reconciler.RoleGroupInfo,
+) reconciler.Reconciler {
+	options := func(o *builder.Options) {
+		o.ClusterName = info.ClusterName
+		o.RoleName = info.RoleName
+		o.RoleGroupName = info.RoleGroupName
+		o.Annotations = info.GetAnnotations()
+		o.Labels = info.GetLabels()
+	}
+
+	builder := &ConfigSecretBuilder{
+		SecretBuilder: *builder.NewSecretBuilder(client, info.GetFullName(), options),
+		configMapBuilder: NewConfigMapBuilder(
+			client,
+			info.GetFullName(),
+			coordiantorSvcFqdn,
+			clusterConfig,
+			catalogs,
+			trinoConfig,
+			options,
+		),
+	}
+
+	return reconciler.NewGenericResourceReconciler(
+		client,
+		builder,
+	)
+}
+
+var _ builder.ConfigBuilder = &ConfigSecretBuilder{}
+
+// ConfigSecretBuilder builds the companion secret of the role group ConfigMap,
+// containing the sensitive properties referenced by the ConfigMap.
+type ConfigSecretBuilder struct {
+	builder.SecretBuilder
+
+	configMapBuilder *ConfigMapBuilder
+}
+
+func (b *ConfigSecretBuilder) Build(ctx context.Context) (ctrlclient.Object, error) {
+	files, err := b.configMapBuilder.getPropertiesFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for fileName, p := range files {
+		// Catalogs are created with their values in dynamic catalog management, see DynamicCatalogManager.
+		if isDynamicCatalogManagement(b.configMapBuilder.ClusterConfig) && isCatalogFile(fileName) {
+			continue
+		}
+		_, secretData := splitSensitiveProperties(fileName, p)
+		for key, value := range secretData {
+			b.AddItem(key, value)
+		}
+	}
+
+	return b.GetObject(), nil
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+func TestIsSensitiveProperty(t *testing.T) {
+	tests := []struct {
+		key       string
+		value     string
+		sensitive bool
+	}{
+		{key: "connection-password", value: "changeme", sensitive: true},
+		{key: "s3.aws-secret-key", value: "changeme", sensitive: true},
+		{key: "http-server.https.keystore.key", value: "changeme", sensitive: true},
+		{key: "iceberg.rest-catalog.oauth2.credential", value: "id:secret", sensitive: true},
+		{
+			key:       "sasl.jaas.config",
+			value:     `org.apache.kafka.common.security.plain.PlainLoginModule required username="trino" password="changeme";`,
+			sensitive: true,
+		},
+		{key: "cassandra.partition-key", value: "id", sensitive: false},
+		{key: "redis.key-prefix-schema-table", value: "true", sensitive: false},
+		{key: "connection-password", value: "${ENV:PASSWORD}", sensitive: false},
+		{key: "connection-password", value: "${FILE:/kubedoop/secret/password}", sensitive: false},
+		{key: "iceberg.rest-catalog.oauth2.credential", value: "${ENV:CLIENT_ID}:${ENV:CLIENT_SECRET}", sensitive: false},
+		{key: "iceberg.rest-catalog.oauth2.credential", value: "id:${ENV:CLIENT_SECRET}", sensitive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isSensitiveProperty(tt.key, tt.value)).To(Equal(tt.sensitive))
+		})
+	}
+}
+
+// The oauth2 credential of an iceberg REST catalog references the client id and secret environment variables,
+// it must stay in the ConfigMap, as trino does not resolve the references in a file referenced by `${FILE:}`.
+func TestIcebergRestOAuth2Credential(t *testing.T) {
+	g := NewWithT(t)
+
+	iceberg, err := catalog.NewIceberg(context.Background(), nil, "lake", &trinov1alpha1.IcebergConnectorSpec{
+		Rest: &trinov1alpha1.IcebergRestCatalogSpec{
+			Uri: "http://polaris:8181/api/catalog",
+			OAuth2: &trinov1alpha1.IcebergRestOAuth2Spec{
+				ClientCredentialsSecret: "polaris-credentials",
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	configProperties, secretData := splitSensitiveProperties("catalog-lake.properties", iceberg.GetConfigProperties())
+	g.Expect(secretData).To(BeEmpty())
+
+	credential, ok := configProperties.Get("iceberg.rest-catalog.oauth2.credential")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(credential).To(Equal("${ENV:CATALOG_LAKE_OAUTH2_CLIENT_ID}:${ENV:CATALOG_LAKE_OAUTH2_CLIENT_SECRET}"))
+}
@@ -2,8 +2,11 @@ package common
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -18,7 +21,9 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	trinosv1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
 	"github.com/zncdatadev/trino-operator/internal/controller/common/authz"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
 )
 
 const (
@@ -29,7 +34,7 @@ const (
 var (
 	ServerTlsMountPath   = path.Join(constants.KubedoopTlsDir, "server")
 	InternalTlsMountPath = path.Join(constants.KubedoopTlsDir, "internal")
-	ClientTlsPath        = path.Join(constants.KubedoopTlsDir, "client")
+	ClientTlsPath        = truststore.Dir
 	DefaultTlsPassphrase = "changeit"
 )
 
@@ -37,6 +42,7 @@ func NewConfigReconciler(
 	client *client.Client,
 	coordiantorSvcFqdn string,
 	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
 	trinoConfig *trinosv1alpha1.ConfigSpec,
 	info reconciler.RoleGroupInfo,
 ) reconciler.Reconciler {
@@ -45,6 +51,7 @@ func NewConfigReconciler(
 		info.GetFullName(),
 		coordiantorSvcFqdn,
 		clusterConfig,
+		catalogs,
 		trinoConfig,
 		func(o *builder.Options) {
 			o.ClusterName = info.ClusterName
@@ -69,6 +76,7 @@ type ConfigMapBuilder struct {
 	TrinoConfig   *trinosv1alpha1.ConfigSpec
 	ClusterConfig *trinosv1alpha1.ClusterConfigSpec
 	TrinoConig    *trinosv1alpha1.ConfigSpec
+	Catalogs      *catalog.TrinoCatalogs
 
 	CoordiantorSvcFqdn string
 
@@ -82,6 +90,7 @@ func NewConfigMapBuilder(
 	name string,
 	coordinatorSvcFqdn string,
 	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
 	trinoConfig *trinosv1alpha1.ConfigSpec,
 	options ...builder.Option,
 ) *ConfigMapBuilder {
@@ -100,6 +109,7 @@ func NewConfigMapBuilder(
 		),
 		CoordiantorSvcFqdn: coordinatorSvcFqdn,
 		ClusterConfig:      clusterConfig,
+		Catalogs:           catalogs,
 		TrinoConfig:        trinoConfig,
 
 		ClusterName:   opts.ClusterName,
@@ -109,31 +119,25 @@ func NewConfigMapBuilder(
 }
 
 func (b *ConfigMapBuilder) Build(ctx context.Context) (ctrlclient.Object, error) {
-	configProperties, err := b.getConfigProperties(ctx)
-	if err != nil {
-		return nil, err
-	}
-	s, err := configProperties.Marshal()
-	if err != nil {
-		return nil, err
-	}
-	b.AddItem("config.properties", s)
-
-	nodeProperties := b.getNodeProperties()
-	s, err = nodeProperties.Marshal()
+	files, err := b.getPropertiesFiles(ctx)
 	if err != nil {
 		return nil, err
 	}
-	b.AddItem("node.properties", s)
-
-	secretProperties := b.getSecurityProperties()
-	s, err = secretProperties.Marshal()
-	if err != nil {
-		return nil, err
+	for fileName, p := range files {
+		// Catalogs are created on the coordinator with SQL in dynamic catalog management, see DynamicCatalogManager.
+		if isDynamicCatalogManagement(b.ClusterConfig) && isCatalogFile(fileName) {
+			continue
+		}
+		// Sensitive properties are rendered to the config secret, see ConfigSecretBuilder.
+		configProperties, _ := splitSensitiveProperties(fileName, p)
+		s, err := configProperties.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b.AddItem(fileName, s)
 	}
-	b.AddItem("secret.properties", s)
 
-	b.AddItem("jvm.config", b.getJvmProperties())
+	b.AddItem("jvm.config", b.getJvmProperties(getPodCatalogs(b.ClusterConfig, b.Catalogs).GetKrb5Conf()))
 	b.AddItem("log.properties", `=info
 `)
 
@@ -152,33 +156,117 @@ func (b *ConfigMapBuilder) Build(ctx context.Context) (ctrlclient.Object, error)
 		b.AddItem(builder.VectorConfigFileName, s)
 	}
 
-	catalogProperties := b.getCatalogProperties()
-	for fileName, data := range catalogProperties {
-		b.AddItem(fileName, data)
-	}
 	return b.GetObject(), nil
 }
 
-// TODO: Refactor this method to use CatalogLabelSelector instead.
-func (b *ConfigMapBuilder) getCatalogProperties() map[string]string {
-	catalogData := make(map[string]string)
-	if b.ClusterConfig != nil && b.ClusterConfig.CatalogProperties != nil {
-		for catalogType, catalogProperties := range b.ClusterConfig.CatalogProperties {
-			p := properties.NewProperties()
-			for key, value := range catalogProperties {
-				p.Add(key, value)
-			}
-			s, err := p.Marshal()
-			if err != nil {
-				continue
-			}
-			fileName := fmt.Sprintf("catalog-%s.properties", catalogType)
-			catalogData[fileName] = s
+// getPropertiesFiles returns the properties files of the role group keyed by file name,
+// including the catalog files.
+func (b *ConfigMapBuilder) getPropertiesFiles(ctx context.Context) (map[string]*properties.Properties, error) {
+	configProperties, err := b.getConfigProperties(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*properties.Properties)
+	for name, p := range getCatalogProperties(b.ClusterConfig, b.Catalogs) {
+		files[getCatalogFileName(name)] = p
+	}
+	files["config.properties"] = configProperties
+	files["node.properties"] = b.getNodeProperties()
+	files["secret.properties"] = b.getSecurityProperties()
+	return files, nil
+}
+
+// getCatalogProperties renders the legacy CatalogProperties and the TrinoCatalogs selected by
+// CatalogLabelSelector, keyed by catalog name. A TrinoCatalog takes precedence over a legacy catalog with the same name.
+// The legacy catalogs are rendered as is, their validation is only reported in the CatalogsResolved condition.
+func getCatalogProperties(
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+) map[string]*properties.Properties {
+	catalogData := make(map[string]*properties.Properties)
+	if clusterConfig != nil && clusterConfig.CatalogProperties != nil {
+		for catalogType, catalogProperties := range clusterConfig.CatalogProperties {
+			catalogData[catalogType] = properties.NewPropertiesFromMap(catalogProperties)
 		}
 	}
+
+	for name, p := range catalogs.GetCatalogProperties() {
+		catalogData[name] = p
+	}
 	return catalogData
 }
 
+// GetCatalogChecksums returns the checksum of the rendered properties of each catalog, keyed by catalog name.
+func GetCatalogChecksums(
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+) (map[string]string, error) {
+	checksums := make(map[string]string)
+	for name, p := range getCatalogProperties(clusterConfig, catalogs) {
+		data, err := p.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256([]byte(data))
+		checksums[name] = hex.EncodeToString(hash[:])
+	}
+	return checksums, nil
+}
+
+// GetPodCatalogChecksum returns the checksum of the catalog config read by the pods at startup, set on
+// the pod template, so the pods restart when it changes. It covers the catalog files and the mounted config
+// read by the catalogs with static catalog management. It is empty if there is no such config.
+func GetPodCatalogChecksum(
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+) (string, error) {
+	if isDynamicCatalogManagement(clusterConfig) {
+		return "", nil
+	}
+	checksums := catalogs.GetConfigChecksums()
+	catalogChecksums, err := GetCatalogChecksums(clusterConfig, catalogs)
+	if err != nil {
+		return "", err
+	}
+	for name, checksum := range catalogChecksums {
+		checksums = append(checksums, "catalog/"+name+"/"+checksum)
+	}
+	if len(checksums) == 0 {
+		return "", nil
+	}
+	sort.Strings(checksums)
+	hash := sha256.Sum256([]byte(strings.Join(checksums, ",")))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// getCatalogFileName returns the config map key of a catalog file,
+// it is moved to the catalog directory by the container startup script.
+func getCatalogFileName(catalogName string) string {
+	return fmt.Sprintf("catalog-%s.properties", catalogName)
+}
+
+func isCatalogFile(fileName string) bool {
+	return strings.HasPrefix(fileName, "catalog-") && strings.HasSuffix(fileName, ".properties")
+}
+
+func isDynamicCatalogManagement(clusterConfig *trinosv1alpha1.ClusterConfigSpec) bool {
+	return clusterConfig != nil && clusterConfig.CatalogManagement == trinosv1alpha1.CatalogManagementDynamic
+}
+
+// getPodCatalogs returns the catalogs whose environment variables, volumes and commands are added to the pods.
+// There are none with dynamic catalog management, the catalogs are only created with CREATE CATALOG,
+// so the pods are not restarted when a catalog is added or changed.
+func getPodCatalogs(
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+) *catalog.TrinoCatalogs {
+	if isDynamicCatalogManagement(clusterConfig) {
+		return &catalog.TrinoCatalogs{}
+	}
+	return catalogs
+}
+
 func (b *ConfigMapBuilder) getDiscoveryUri() string {
 	schema := HttpScheme
 	port := int(trinosv1alpha1.HttpPort)
@@ -236,6 +324,12 @@ func (b *ConfigMapBuilder) getConfigProperties(ctx context.Context) (*properties
 		p.Add("http-server.http.port", strconv.Itoa(int(trinosv1alpha1.HttpPort)))
 	}
 
+	if isDynamicCatalogManagement(b.ClusterConfig) {
+		p.Add("catalog.management", "dynamic")
+		p.Add("catalog.store", "file")
+		p.Add("catalog.config-dir", DynamicCatalogStoreDir)
+	}
+
 	p.Add("log.compression", "none")
 	p.Add("log.format", "json")
 	p.Add("log.max-size", "5MB")
@@ -298,7 +392,8 @@ func (b *ConfigMapBuilder) getHeapSize(factor float64) string {
 	return fmt.Sprintf("%d%s", int(heapSize*factor), unit)
 }
 
-func (b *ConfigMapBuilder) getJvmProperties() string {
+// getJvmProperties returns the jvm.config, krb5Conf is the krb5.conf used by kerberized catalogs, if any.
+func (b *ConfigMapBuilder) getJvmProperties(krb5Conf string) string {
 
 	javaagentPath := path.Join(constants.KubedoopJmxDir, "jmx_prometheus_javaagent.jar")
 	jmxConfigPath := path.Join(constants.KubedoopJmxDir, "config.yaml")
@@ -322,11 +417,14 @@ func (b *ConfigMapBuilder) getJvmProperties() string {
 -XX:+EnableDynamicAgentLoading
 -XX:+UnlockDiagnosticVMOptions
 -XX:G1NumCollectionsKeepPinned=10000000
--Djava.net.ssl.trustStore=` + path.Join(constants.KubedoopTlsDir, "client", "truststore.p12") + `
--Djava.net.ssl.trustStorePassword=` + DefaultTlsPassphrase + `
--Djava.net.ssl.trustStoreType=PKCS12
+-Djavax.net.ssl.trustStore=` + truststore.Path + `
+-Djavax.net.ssl.trustStorePassword=` + truststore.Passphrase + `
+-Djavax.net.ssl.trustStoreType=PKCS12
 -Djava.secret.properties=` + path.Join(constants.KubedoopConfigDir, "secret.properties") + `
 -javaagent:` + javaagentPath + fmt.Sprintf("=%d:", trinosv1alpha1.MetricsPort) + jmxConfigPath + `
 `
+	if krb5Conf != "" {
+		jvm += "-Djava.security.krb5.conf=" + krb5Conf + "\n"
+	}
 	return util.IndentTab4Spaces(jvm)
 }
@@ -0,0 +1,548 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/config/properties"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	"github.com/zncdatadev/operator-go/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	trinosv1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+const (
+	// DynamicCatalogUser is the trino user of the statements issued by the operator without credentials.
+	// It is only identified by the X-Trino-User header, which a coordinator with an authentication rejects.
+	DynamicCatalogUser = "trino-operator"
+
+	// dynamicCatalogCheckSuffix is appended to the name of a changed catalog, it is created under this name
+	// before the existing catalog is dropped, so a catalog failing to be created does not replace the existing one.
+	dynamicCatalogCheckSuffix = "__operator_check"
+
+	queryPollInitialInterval = 50 * time.Millisecond
+	queryPollMaxInterval     = 2 * time.Second
+)
+
+var (
+	// DynamicCatalogStoreDir is where the shared catalog store claim is mounted.
+	DynamicCatalogStoreDir = path.Join(constants.KubedoopRoot, "catalog-store")
+
+	envReferenceRegex = regexp.MustCompile(`\$\{ENV:([^}]+)\}`)
+
+	// errUnresolvedValue is returned when a ConfigMap or Secret key referenced by a catalog does not exist.
+	errUnresolvedValue = errors.New("unable to resolve catalog value")
+
+	secretClassGVK = schema.GroupVersionKind{Group: "secrets.kubedoop.dev", Version: "v1alpha1", Kind: "SecretClass"}
+
+	dynamicCatalogLogger = ctrl.Log.WithName("dynamic-catalog")
+)
+
+// getDynamicCatalogStoreClaimName returns the claim of the shared catalog store, or an empty string.
+func getDynamicCatalogStoreClaimName(clusterConfig *trinosv1alpha1.ClusterConfigSpec) string {
+	if !isDynamicCatalogManagement(clusterConfig) || clusterConfig.DynamicCatalog == nil {
+		return ""
+	}
+	return clusterConfig.DynamicCatalog.StoreClaimName
+}
+
+// DynamicCatalogManager applies the catalogs of a cluster to the coordinator with
+// CREATE CATALOG and DROP CATALOG statements, when dynamic catalog management is enabled.
+type DynamicCatalogManager struct {
+	Client         *client.Client
+	ClusterConfig  *trinosv1alpha1.ClusterConfigSpec
+	Catalogs       *catalog.TrinoCatalogs
+	CoordinatorUri string
+
+	httpClient *http.Client
+	username   string
+	password   string
+}
+
+// NewDynamicCatalogManager returns a manager connecting to the coordinator role service of the cluster,
+// over https when TLS is enabled.
+func NewDynamicCatalogManager(
+	client *client.Client,
+	clusterInfo reconciler.ClusterInfo,
+	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
+) *DynamicCatalogManager {
+	coordinatorRoleInfo := reconciler.RoleInfo{ClusterInfo: clusterInfo, RoleName: string(RoleCoordinator)}
+	host := strings.Join(
+		[]string{coordinatorRoleInfo.GetFullName(), client.GetOwnerNamespace(), "svc.cluster.local"},
+		".",
+	)
+	scheme, port := HttpScheme, trinosv1alpha1.HttpPort
+	if clusterConfig != nil && clusterConfig.Tls != nil {
+		scheme, port = HttpsScheme, trinosv1alpha1.HttpsPort
+	}
+	return &DynamicCatalogManager{
+		Client:         client,
+		ClusterConfig:  clusterConfig,
+		Catalogs:       catalogs,
+		CoordinatorUri: scheme + "://" + host + ":" + strconv.Itoa(int(port)),
+	}
+}
+
+// setupHttpClient reads the credentials secret of the operator user, and creates the http client
+// verifying the coordinator with the CA of the secret, or the CA of the server secret class of the cluster.
+// Without credentials, the coordinator must not have an authentication, as the statements are not authenticated.
+func (m *DynamicCatalogManager) setupHttpClient(ctx context.Context) error {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	name := ""
+	if m.ClusterConfig.DynamicCatalog != nil {
+		name = m.ClusterConfig.DynamicCatalog.CredentialsSecret
+	}
+	if name == "" && len(m.ClusterConfig.Authentication) > 0 {
+		return errors.New("the cluster has an authentication, dynamicCatalog.credentialsSecret is required to issue catalog statements")
+	}
+
+	if name != "" {
+		if m.ClusterConfig.Tls == nil {
+			return fmt.Errorf("credentials secret %s requires tls, credentials are not sent over http", name)
+		}
+
+		secret := &corev1.Secret{}
+		if err := m.Client.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: m.Client.GetOwnerNamespace(), Name: name}, secret); err != nil {
+			return fmt.Errorf("get credentials secret %s: %w", name, err)
+		}
+		m.username = string(secret.Data["username"])
+		m.password = string(secret.Data["password"])
+		if m.username == "" || m.password == "" {
+			return fmt.Errorf("credentials secret %s must contain username and password", name)
+		}
+		if ca, ok := secret.Data["ca.crt"]; ok {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return fmt.Errorf("credentials secret %s: invalid ca.crt", name)
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+
+	if m.ClusterConfig.Tls != nil && tlsConfig.RootCAs == nil {
+		pool, err := m.getSecretClassCa(ctx, m.ClusterConfig.Tls.ServerSecretClass)
+		if err != nil {
+			return err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	m.httpClient = &http.Client{Timeout: 30 * time.Second, Transport: transport}
+	return nil
+}
+
+// getSecretClassCa returns the CA certificates of a secret class with an autoTls backend,
+// read from its CA secret, which is what secret-operator signs the coordinator certificate with.
+func (m *DynamicCatalogManager) getSecretClassCa(ctx context.Context, name string) (*x509.CertPool, error) {
+	if name == "" {
+		name = "tls"
+	}
+	secretClass := &unstructured.Unstructured{}
+	secretClass.SetGroupVersionKind(secretClassGVK)
+	if err := m.Client.Client.Get(ctx, ctrlclient.ObjectKey{Name: name}, secretClass); err != nil {
+		return nil, fmt.Errorf("get secret class %s: %w", name, err)
+	}
+	secretName, _, _ := unstructured.NestedString(secretClass.Object, "spec", "backend", "autoTls", "ca", "secret", "name")
+	secretNamespace, _, _ := unstructured.NestedString(secretClass.Object, "spec", "backend", "autoTls", "ca", "secret", "namespace")
+	if secretName == "" || secretNamespace == "" {
+		return nil, fmt.Errorf("secret class %s has no autoTls CA secret, set ca.crt in dynamicCatalog.credentialsSecret", name)
+	}
+
+	secret := &corev1.Secret{}
+	if err := m.Client.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: secretNamespace, Name: secretName}, secret); err != nil {
+		return nil, fmt.Errorf("get CA secret of secret class %s: %w", name, err)
+	}
+	pool := x509.NewCertPool()
+	found := false
+	for key, value := range secret.Data {
+		if strings.HasSuffix(key, ".crt") && pool.AppendCertsFromPEM(value) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("CA secret %s/%s of secret class %s has no certificate", secretNamespace, secretName, name)
+	}
+	return pool, nil
+}
+
+// Apply creates the catalogs missing on the coordinator or changed since they were applied,
+// and drops the catalogs previously applied but no longer selected by the cluster.
+// The status of each selected catalog is returned, an error is returned when the coordinator is not reachable.
+func (m *DynamicCatalogManager) Apply(
+	ctx context.Context,
+	applied []trinosv1alpha1.DynamicCatalogStatus,
+) ([]trinosv1alpha1.DynamicCatalogStatus, error) {
+	// The catalogs are only stored by the coordinator they are created on, so they must be in a shared store
+	// to survive restarts and be seen by all coordinators.
+	if getDynamicCatalogStoreClaimName(m.ClusterConfig) == "" {
+		return nil, errors.New("dynamic catalog management requires dynamicCatalog.storeClaimName")
+	}
+	if err := m.setupHttpClient(ctx); err != nil {
+		return nil, err
+	}
+
+	desired := getCatalogProperties(m.ClusterConfig, m.Catalogs)
+
+	rows, err := m.query(ctx, "SHOW CATALOGS")
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		name := fmt.Sprint(row[0])
+		// A check catalog is left behind when the operator stopped while applying a changed catalog.
+		if strings.HasSuffix(name, dynamicCatalogCheckSuffix) {
+			if _, err := m.query(ctx, "DROP CATALOG IF EXISTS "+quoteIdentifier(name)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		existing[name] = true
+	}
+
+	previous := make(map[string]trinosv1alpha1.DynamicCatalogStatus, len(applied))
+	for _, s := range applied {
+		previous[s.Name] = s
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := make([]trinosv1alpha1.DynamicCatalogStatus, 0, len(names))
+	for _, name := range names {
+		status, err := m.apply(ctx, name, desired[name], existing[name], previous[name])
+		if err != nil {
+			return nil, err
+		}
+		status.Generation = m.Catalogs.GetGeneration(name)
+		statuses = append(statuses, status)
+	}
+
+	for _, s := range applied {
+		if _, ok := desired[s.Name]; ok || !existing[s.Name] {
+			continue
+		}
+		dynamicCatalogLogger.Info("drop catalog", "catalog", s.Name)
+		if _, err := m.query(ctx, "DROP CATALOG "+quoteIdentifier(s.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+// apply resolves the properties of the catalog and applies them. A catalog mounting files to the pods,
+// or referencing a missing ConfigMap or Secret key, is reported in the status.
+func (m *DynamicCatalogManager) apply(
+	ctx context.Context,
+	name string,
+	p *properties.Properties,
+	exists bool,
+	previous trinosv1alpha1.DynamicCatalogStatus,
+) (trinosv1alpha1.DynamicCatalogStatus, error) {
+	if c := m.Catalogs.GetCatalog(name); c != nil && c.MountsFiles() {
+		return trinosv1alpha1.DynamicCatalogStatus{
+			Name:    name,
+			Message: "the catalog mounts files to the pods, which is not supported with dynamic catalog management",
+		}, nil
+	}
+
+	resolved, err := m.resolveProperties(ctx, p)
+	if errors.Is(err, errUnresolvedValue) {
+		return trinosv1alpha1.DynamicCatalogStatus{Name: name, Message: err.Error()}, nil
+	} else if err != nil {
+		return trinosv1alpha1.DynamicCatalogStatus{}, err
+	}
+
+	// The checksum covers the resolved values, so a rotated secret value recreates the catalog.
+	data, err := resolved.Marshal()
+	if err != nil {
+		return trinosv1alpha1.DynamicCatalogStatus{}, err
+	}
+	hash := sha256.Sum256([]byte(data))
+	return m.applyCatalog(ctx, name, hex.EncodeToString(hash[:]), resolved, exists, previous)
+}
+
+// resolveProperties returns the properties with the references to the environment variables of the catalogs
+// replaced by their values, as the environment variables are not added to the pods.
+// Other references, e.g. to the environment variables of envOverrides, are left to trino.
+func (m *DynamicCatalogManager) resolveProperties(ctx context.Context, p *properties.Properties) (*properties.Properties, error) {
+	envVars := make(map[string]corev1.EnvVar)
+	for _, env := range m.Catalogs.GetEnvVars() {
+		envVars[env.Name] = env
+	}
+
+	resolved := properties.NewProperties()
+	for _, key := range p.Keys() {
+		value, _ := p.Get(key)
+		var resolveErr error
+		value = envReferenceRegex.ReplaceAllStringFunc(value, func(reference string) string {
+			env, ok := envVars[envReferenceRegex.FindStringSubmatch(reference)[1]]
+			if !ok || resolveErr != nil {
+				return reference
+			}
+			envValue, err := m.resolveEnvVar(ctx, env)
+			if err != nil {
+				resolveErr = err
+			}
+			return envValue
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		resolved.Add(key, value)
+	}
+	return resolved, nil
+}
+
+// resolveEnvVar returns the value of an environment variable of a catalog, read from its ConfigMap or Secret key.
+// A missing object or key returns errUnresolvedValue.
+func (m *DynamicCatalogManager) resolveEnvVar(ctx context.Context, env corev1.EnvVar) (string, error) {
+	if env.ValueFrom == nil {
+		return env.Value, nil
+	}
+
+	switch {
+	case env.ValueFrom.SecretKeyRef != nil:
+		ref := env.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		key := ctrlclient.ObjectKey{Namespace: m.Client.GetOwnerNamespace(), Name: ref.Name}
+		if err := m.Client.Client.Get(ctx, key, secret); apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: secret %s not found", errUnresolvedValue, ref.Name)
+		} else if err != nil {
+			return "", err
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("%w: secret %s has no key %s", errUnresolvedValue, ref.Name, ref.Key)
+		}
+		return string(value), nil
+	case env.ValueFrom.ConfigMapKeyRef != nil:
+		ref := env.ValueFrom.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		key := ctrlclient.ObjectKey{Namespace: m.Client.GetOwnerNamespace(), Name: ref.Name}
+		if err := m.Client.Client.Get(ctx, key, cm); apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: configmap %s not found", errUnresolvedValue, ref.Name)
+		} else if err != nil {
+			return "", err
+		}
+		value, ok := cm.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("%w: configmap %s has no key %s", errUnresolvedValue, ref.Name, ref.Key)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: environment variable %s has no supported source", errUnresolvedValue, env.Name)
+}
+
+// applyCatalog creates the catalog, or recreates it when its checksum changed.
+// A changed catalog is first created under a check name, so a catalog failing to be created is reported
+// and the existing catalog is kept. Queries running on the existing catalog fail when it is dropped.
+// Errors of the statements are reported in the status, only connection errors are returned.
+func (m *DynamicCatalogManager) applyCatalog(
+	ctx context.Context,
+	name string,
+	checksum string,
+	p *properties.Properties,
+	exists bool,
+	previous trinosv1alpha1.DynamicCatalogStatus,
+) (trinosv1alpha1.DynamicCatalogStatus, error) {
+	status := trinosv1alpha1.DynamicCatalogStatus{Name: name, Checksum: checksum}
+
+	if exists && previous.Applied && previous.Checksum == status.Checksum {
+		status.Applied = true
+		return status, nil
+	}
+
+	statement, err := getCreateCatalogStatement(name, p)
+	if err != nil {
+		status.Message = err.Error()
+		return status, nil
+	}
+
+	if exists {
+		checkName := name + dynamicCatalogCheckSuffix
+		checkStatement, err := getCreateCatalogStatement(checkName, p)
+		if err != nil {
+			status.Message = err.Error()
+			return status, nil
+		}
+		if _, err := m.query(ctx, checkStatement); err != nil {
+			return m.statementFailed(status, err)
+		}
+		if _, err := m.query(ctx, "DROP CATALOG IF EXISTS "+quoteIdentifier(checkName)); err != nil {
+			return m.statementFailed(status, err)
+		}
+
+		dynamicCatalogLogger.Info("drop changed catalog", "catalog", name)
+		if _, err := m.query(ctx, "DROP CATALOG IF EXISTS "+quoteIdentifier(name)); err != nil {
+			return m.statementFailed(status, err)
+		}
+	}
+
+	dynamicCatalogLogger.Info("create catalog", "catalog", name)
+	if _, err := m.query(ctx, statement); err != nil {
+		return m.statementFailed(status, err)
+	}
+	status.Applied = true
+	return status, nil
+}
+
+// statementFailed reports the error of a failed statement in the status.
+func (m *DynamicCatalogManager) statementFailed(
+	status trinosv1alpha1.DynamicCatalogStatus,
+	err error,
+) (trinosv1alpha1.DynamicCatalogStatus, error) {
+	var queryErr *queryError
+	if !errors.As(err, &queryErr) {
+		return status, err
+	}
+	status.Message = queryErr.Error()
+	return status, nil
+}
+
+// getCreateCatalogStatement renders the CREATE CATALOG statement of the catalog properties.
+func getCreateCatalogStatement(name string, p *properties.Properties) (string, error) {
+	connectorName, ok := p.Get("connector.name")
+	if !ok || connectorName == "" {
+		return "", fmt.Errorf("catalog %s has no connector.name", name)
+	}
+
+	items := make([]string, 0, len(p.Keys()))
+	for _, key := range p.Keys() {
+		if key == "connector.name" {
+			continue
+		}
+		value, _ := p.Get(key)
+		items = append(items, quoteIdentifier(key)+" = "+quoteLiteral(value))
+	}
+
+	statement := "CREATE CATALOG " + quoteIdentifier(name) + " USING " + connectorName
+	if len(items) > 0 {
+		statement += " WITH (" + strings.Join(items, ", ") + ")"
+	}
+	return statement, nil
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// queryError is an error reported by trino for a statement.
+type queryError struct {
+	Message string `json:"message"`
+}
+
+func (e *queryError) Error() string {
+	return e.Message
+}
+
+type queryResults struct {
+	NextUri string          `json:"nextUri"`
+	Data    [][]interface{} `json:"data"`
+	Error   *queryError     `json:"error"`
+}
+
+// query executes the statement with the trino client REST API, and returns the rows of the result.
+// The next results are polled with an exponential backoff.
+func (m *DynamicCatalogManager) query(ctx context.Context, statement string) ([][]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.CoordinatorUri+"/v1/statement", bytes.NewBufferString(statement))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, 0)
+	interval := queryPollInitialInterval
+	for req != nil {
+		if m.username != "" {
+			req.SetBasicAuth(m.username, m.password)
+			req.Header.Set("X-Trino-User", m.username)
+		} else {
+			req.Header.Set("X-Trino-User", DynamicCatalogUser)
+		}
+		results, err := m.do(req)
+		if err != nil {
+			return nil, err
+		}
+		if results.Error != nil {
+			return nil, results.Error
+		}
+		rows = append(rows, results.Data...)
+
+		req = nil
+		if results.NextUri != "" {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(interval):
+			}
+			interval = min(interval*2, queryPollMaxInterval)
+
+			if req, err = http.NewRequestWithContext(ctx, http.MethodGet, results.NextUri, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (m *DynamicCatalogManager) do(req *http.Request) (*queryResults, error) {
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("trino coordinator responded %s: %s", resp.Status, string(body))
+	}
+
+	results := &queryResults{}
+	if err := json.Unmarshal(body, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,395 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/zncdatadev/operator-go/pkg/builder"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"github.com/zncdatadev/operator-go/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+// fakeCoordinator serves the trino client REST API, it records the statements and the users issuing them.
+type fakeCoordinator struct {
+	mu         sync.Mutex
+	catalogs   []string
+	statements []string
+	users      []string
+	authorized []string
+}
+
+func (c *fakeCoordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The rows of SHOW CATALOGS are returned by a second page, so the next uri is polled.
+	if r.Method == http.MethodGet && r.URL.Path == "/v1/statement/next" {
+		data := make([][]interface{}, 0, len(c.catalogs))
+		for _, name := range c.catalogs {
+			data = append(data, []interface{}{name})
+		}
+		_ = json.NewEncoder(w).Encode(queryResults{Data: data})
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	statement := string(body)
+	c.statements = append(c.statements, statement)
+	c.users = append(c.users, r.Header.Get("X-Trino-User"))
+	if username, password, ok := r.BasicAuth(); ok {
+		c.authorized = append(c.authorized, username+":"+password)
+	}
+
+	switch {
+	case statement == "SHOW CATALOGS":
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		_ = json.NewEncoder(w).Encode(queryResults{NextUri: scheme + "://" + r.Host + "/v1/statement/next"})
+	case strings.Contains(statement, "USING broken"):
+		_ = json.NewEncoder(w).Encode(queryResults{Error: &queryError{Message: "No factory for connector 'broken'"}})
+	default:
+		_ = json.NewEncoder(w).Encode(queryResults{})
+	}
+}
+
+func newDynamicCatalogClusterConfig() *trinov1alpha1.ClusterConfigSpec {
+	return &trinov1alpha1.ClusterConfigSpec{
+		CatalogManagement: trinov1alpha1.CatalogManagementDynamic,
+		DynamicCatalog:    &trinov1alpha1.DynamicCatalogSpec{StoreClaimName: "catalog-store"},
+	}
+}
+
+func newDynamicCatalogClient(t *testing.T, objs ...ctrlclient.Object) *client.Client {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := trinov1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	scheme.AddKnownTypeWithName(secretClassGVK, &unstructured.Unstructured{})
+
+	owner := &trinov1alpha1.TrinoCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino"}}
+	return &client.Client{
+		Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		OwnerReference: owner,
+	}
+}
+
+func newDynamicCatalogs(t *testing.T, c *client.Client, objs ...*trinov1alpha1.TrinoCatalog) *catalog.TrinoCatalogs {
+	catalogs := &catalog.TrinoCatalogs{}
+	for _, obj := range objs {
+		resolved, err := catalog.NewCatalog(context.Background(), c, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		catalogs.Catalogs = append(catalogs.Catalogs, resolved)
+	}
+	return catalogs
+}
+
+func newPostgresqlCatalog(name string, generation int64) *trinov1alpha1.TrinoCatalog {
+	return &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: name, Generation: generation},
+		Spec: trinov1alpha1.TrinoCatalogSpec{
+			Connector: trinov1alpha1.ConnectorSpec{
+				Postgresql: &trinov1alpha1.JdbcConnectorSpec{
+					Host:              "postgresql",
+					Database:          "sales",
+					CredentialsSecret: "postgresql-credentials",
+				},
+			},
+		},
+	}
+}
+
+func newKafkaCatalog(name string) *trinov1alpha1.TrinoCatalog {
+	return &trinov1alpha1.TrinoCatalog{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: name, Generation: 1},
+		Spec: trinov1alpha1.TrinoCatalogSpec{
+			Connector: trinov1alpha1.ConnectorSpec{
+				Kafka: &trinov1alpha1.KafkaConnectorSpec{
+					BootstrapServers:  []string{"kafka:9092"},
+					TableDescriptions: []string{"kafka-tables"},
+				},
+			},
+		},
+	}
+}
+
+var postgresqlCredentials = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "postgresql-credentials"},
+	Data:       map[string][]byte{"username": []byte("trino"), "password": []byte("it's secret")},
+}
+
+func TestDynamicCatalogApply(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	coordinator := &fakeCoordinator{catalogs: []string{"system", "sales", "removed", "sales__operator_check"}}
+	server := httptest.NewServer(coordinator)
+	defer server.Close()
+
+	c := newDynamicCatalogClient(t, postgresqlCredentials)
+	clusterConfig := newDynamicCatalogClusterConfig()
+	clusterConfig.CatalogProperties = map[string]map[string]string{
+		"broken": {"connector.name": "broken"},
+	}
+	catalogs := newDynamicCatalogs(t, c,
+		newPostgresqlCatalog("sales", 2),
+		newPostgresqlCatalog("orders", 1),
+		newKafkaCatalog("events"),
+	)
+	manager := &DynamicCatalogManager{
+		Client:         c,
+		ClusterConfig:  clusterConfig,
+		Catalogs:       catalogs,
+		CoordinatorUri: server.URL,
+	}
+
+	statuses, err := manager.Apply(ctx, []trinov1alpha1.DynamicCatalogStatus{
+		{Name: "sales", Checksum: "previous", Applied: true},
+		{Name: "removed", Checksum: "previous", Applied: true},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(statuses).To(HaveLen(4))
+	g.Expect(statuses[0]).To(Equal(trinov1alpha1.DynamicCatalogStatus{
+		Name:     "broken",
+		Checksum: statuses[0].Checksum,
+		Message:  "No factory for connector 'broken'",
+	}))
+	g.Expect(statuses[1]).To(Equal(trinov1alpha1.DynamicCatalogStatus{
+		Name:       "events",
+		Generation: 1,
+		Message:    "the catalog mounts files to the pods, which is not supported with dynamic catalog management",
+	}))
+	g.Expect(statuses[2].Name).To(Equal("orders"))
+	g.Expect(statuses[2].Applied).To(BeTrue())
+	g.Expect(statuses[3].Name).To(Equal("sales"))
+	g.Expect(statuses[3].Applied).To(BeTrue())
+	g.Expect(statuses[3].Generation).To(Equal(int64(2)))
+	g.Expect(statuses[3].Checksum).NotTo(Equal("previous"))
+
+	// The credentials are resolved by the operator and sent in the statement, nothing is added to the pods.
+	createOrders := `CREATE CATALOG "orders" USING postgresql WITH (` +
+		`"connection-password" = 'it''s secret', ` +
+		`"connection-url" = 'jdbc:postgresql://postgresql:5432/sales', ` +
+		`"connection-user" = 'trino')`
+	g.Expect(coordinator.statements).To(Equal([]string{
+		"SHOW CATALOGS",
+		`DROP CATALOG IF EXISTS "sales__operator_check"`,
+		`CREATE CATALOG "broken" USING broken`,
+		createOrders,
+		strings.Replace(createOrders, `"orders"`, `"sales__operator_check"`, 1),
+		`DROP CATALOG IF EXISTS "sales__operator_check"`,
+		`DROP CATALOG IF EXISTS "sales"`,
+		strings.Replace(createOrders, `"orders"`, `"sales"`, 1),
+		`DROP CATALOG "removed"`,
+	}))
+	g.Expect(coordinator.users).To(HaveEach(DynamicCatalogUser))
+	g.Expect(coordinator.authorized).To(BeEmpty())
+
+	// The applied catalogs are not created again while their checksum is unchanged,
+	// the failed catalog is checked again before the existing one is replaced.
+	coordinator.catalogs = []string{"system", "broken", "orders", "sales"}
+	coordinator.statements = nil
+	_, err = manager.Apply(ctx, statuses)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(coordinator.statements).To(Equal([]string{
+		"SHOW CATALOGS",
+		`CREATE CATALOG "broken__operator_check" USING broken`,
+	}))
+}
+
+func TestDynamicCatalogApplyUnresolvedValue(t *testing.T) {
+	g := NewWithT(t)
+
+	coordinator := &fakeCoordinator{}
+	server := httptest.NewServer(coordinator)
+	defer server.Close()
+
+	c := newDynamicCatalogClient(t)
+	manager := &DynamicCatalogManager{
+		Client:         c,
+		ClusterConfig:  newDynamicCatalogClusterConfig(),
+		Catalogs:       newDynamicCatalogs(t, c, newPostgresqlCatalog("sales", 1)),
+		CoordinatorUri: server.URL,
+	}
+
+	statuses, err := manager.Apply(context.Background(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(Equal([]trinov1alpha1.DynamicCatalogStatus{{
+		Name:       "sales",
+		Generation: 1,
+		Message:    "unable to resolve catalog value: secret postgresql-credentials not found",
+	}}))
+	g.Expect(coordinator.statements).To(Equal([]string{"SHOW CATALOGS"}))
+}
+
+func TestDynamicCatalogApplyTls(t *testing.T) {
+	g := NewWithT(t)
+
+	coordinator := &fakeCoordinator{}
+	server := httptest.NewTLSServer(coordinator)
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	secretClass := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "trino-tls"},
+		"spec": map[string]interface{}{
+			"backend": map[string]interface{}{
+				"autoTls": map[string]interface{}{
+					"ca": map[string]interface{}{
+						"secret": map[string]interface{}{"name": "secret-provisioner-tls-ca", "namespace": "kubedoop"},
+					},
+				},
+			},
+		},
+	}}
+	secretClass.SetGroupVersionKind(secretClassGVK)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kubedoop", Name: "secret-provisioner-tls-ca"},
+		Data:       map[string][]byte{"ca.crt": ca, "ca.key": []byte("key")},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "operator-credentials"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("changeme")},
+	}
+
+	clusterConfig := newDynamicCatalogClusterConfig()
+	clusterConfig.Tls = &trinov1alpha1.TlsSpec{ServerSecretClass: "trino-tls"}
+	clusterConfig.Authentication = []trinov1alpha1.AuthenticationSpec{{AuthenticationClass: "ldap"}}
+
+	tests := []struct {
+		name           string
+		objs           []ctrlclient.Object
+		credentials    string
+		wantErr        string
+		wantAuthorized []string
+	}{
+		{
+			name:           "secret class ca",
+			objs:           []ctrlclient.Object{secretClass, caSecret, credentials},
+			credentials:    "operator-credentials",
+			wantAuthorized: []string{"admin:changeme"},
+		},
+		{
+			name:        "missing ca",
+			objs:        []ctrlclient.Object{credentials},
+			credentials: "operator-credentials",
+			wantErr:     "get secret class trino-tls",
+		},
+		{
+			name:    "authentication without credentials",
+			objs:    []ctrlclient.Object{secretClass, caSecret},
+			wantErr: "the cluster has an authentication, dynamicCatalog.credentialsSecret is required to issue catalog statements",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			coordinator.authorized = nil
+
+			config := clusterConfig.DeepCopy()
+			config.DynamicCatalog.CredentialsSecret = tt.credentials
+			manager := &DynamicCatalogManager{
+				Client:         newDynamicCatalogClient(t, tt.objs...),
+				ClusterConfig:  config,
+				Catalogs:       &catalog.TrinoCatalogs{},
+				CoordinatorUri: server.URL,
+			}
+
+			_, err := manager.Apply(context.Background(), nil)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(coordinator.authorized).To(Equal(tt.wantAuthorized))
+		})
+	}
+	g.Expect(coordinator.users).To(HaveEach("admin"))
+}
+
+func TestDynamicCatalogApplyRequiresStore(t *testing.T) {
+	g := NewWithT(t)
+
+	manager := &DynamicCatalogManager{
+		Client: newDynamicCatalogClient(t),
+		ClusterConfig: &trinov1alpha1.ClusterConfigSpec{
+			CatalogManagement: trinov1alpha1.CatalogManagementDynamic,
+		},
+		Catalogs: &catalog.TrinoCatalogs{},
+	}
+	_, err := manager.Apply(context.Background(), nil)
+	g.Expect(err).To(MatchError("dynamic catalog management requires dynamicCatalog.storeClaimName"))
+}
+
+// Adding a catalog with dynamic catalog management must not change the resources of the pods,
+// so the pods are not restarted.
+func TestDynamicCatalogPodResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	c := newDynamicCatalogClient(t, postgresqlCredentials)
+	clusterConfig := newDynamicCatalogClusterConfig()
+	options := func(o *builder.Options) {
+		o.ClusterName = "trino"
+		o.RoleName = string(RoleCoordinator)
+		o.RoleGroupName = "default"
+	}
+	image := util.NewImage(trinov1alpha1.DefaultProductName, "0.0.0-dev", trinov1alpha1.DefaultProductVersion)
+
+	build := func(catalogs *catalog.TrinoCatalogs) []ctrlclient.Object {
+		statefulSet, err := NewStatefulSetBuilder(c, "trino-coordinator-default", ptr.To[int32](1), image,
+			clusterConfig, catalogs, nil, nil, nil, options).Build(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		configMapBuilder := NewConfigMapBuilder(c, "trino-coordinator-default", "trino-coordinator", clusterConfig,
+			catalogs, nil, options)
+		configMap, err := configMapBuilder.Build(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		secretBuilder := &ConfigSecretBuilder{
+			SecretBuilder:    *builder.NewSecretBuilder(c, "trino-coordinator-default", options),
+			configMapBuilder: NewConfigMapBuilder(c, "trino-coordinator-default", "trino-coordinator", clusterConfig, catalogs, nil, options),
+		}
+		secret, err := secretBuilder.Build(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return []ctrlclient.Object{statefulSet, configMap, secret}
+	}
+
+	without := build(&catalog.TrinoCatalogs{})
+	with := build(newDynamicCatalogs(t, c, newPostgresqlCatalog("sales", 1), newKafkaCatalog("events")))
+	g.Expect(with).To(Equal(without))
+
+	// The same catalogs are added to the pod template with static catalog management.
+	clusterConfig = &trinov1alpha1.ClusterConfigSpec{}
+	without = build(&catalog.TrinoCatalogs{})
+	with = build(newDynamicCatalogs(t, c, newPostgresqlCatalog("sales", 1), newKafkaCatalog("events")))
+	g.Expect(with[0]).NotTo(Equal(without[0]))
+}
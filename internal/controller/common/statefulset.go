@@ -19,7 +19,9 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	trinosv1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
 	"github.com/zncdatadev/trino-operator/internal/controller/common/authz"
+	"github.com/zncdatadev/trino-operator/internal/controller/common/truststore"
 )
 
 var (
@@ -28,17 +30,20 @@ var (
 	TrinoDataDir        = constants.KubedoopDataDir
 	TrinoLogDir         = constants.KubedoopLogDir
 
-	TrinoConfigVolumeName      = "config"
-	TrinoDataVolumeName        = "data"
-	TrinoLogVolumeName         = "log"
-	TrinoServerTlsVolumeName   = "server-tls"
-	TrinoInternalTlsVolumeName = "internal-tls"
-	TrinoClientTlsVolumeName   = "client-tls"
+	TrinoConfigVolumeName       = "config"
+	TrinoConfigSecretVolumeName = "config-secret"
+	TrinoDataVolumeName         = "data"
+	TrinoLogVolumeName          = "log"
+	TrinoServerTlsVolumeName    = "server-tls"
+	TrinoInternalTlsVolumeName  = "internal-tls"
+	TrinoClientTlsVolumeName    = "client-tls"
+	TrinoCatalogStoreVolumeName = "catalog-store"
 )
 
 func NewStatefulSetReconciler(
 	client *client.Client,
 	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
 	roleGroupInfo reconciler.RoleGroupInfo,
 	image *util.Image,
 	stopped bool,
@@ -67,6 +72,7 @@ func NewStatefulSetReconciler(
 		replicas,
 		image,
 		clusterConfig,
+		catalogs,
 		ports,
 		overrides,
 		commonsRoleGroupConfig,
@@ -86,6 +92,7 @@ type StatefulSetBuilder struct {
 	builder.StatefulSet
 
 	ClusterConfig *trinosv1alpha1.ClusterConfigSpec
+	Catalogs      *catalog.TrinoCatalogs
 	Resource      *commonsv1alpha1.ResourcesSpec
 	Image         *util.Image
 	ClusterName   string
@@ -99,6 +106,7 @@ func NewStatefulSetBuilder(
 	replicas *int32,
 	image *util.Image,
 	clusterConfig *trinosv1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
 	ports []corev1.ContainerPort,
 	overrides *commonsv1alpha1.OverridesSpec,
 	roleGroupConfig *commonsv1alpha1.RoleGroupConfigSpec,
@@ -121,6 +129,7 @@ func NewStatefulSetBuilder(
 			options...,
 		),
 		ClusterConfig: clusterConfig,
+		Catalogs:      catalogs,
 		RoleName:      opts.RoleName,
 		ClusterName:   opts.ClusterName,
 		Image:         image,
@@ -146,6 +155,16 @@ func (b *StatefulSetBuilder) Build(ctx context.Context) (ctrlclient.Object, erro
 	if err != nil {
 		return nil, err
 	}
+	checksum, err := GetPodCatalogChecksum(b.ClusterConfig, b.Catalogs)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != "" {
+		if obj.Spec.Template.Annotations == nil {
+			obj.Spec.Template.Annotations = map[string]string{}
+		}
+		obj.Spec.Template.Annotations[catalog.AnnotationConfigChecksum] = checksum
+	}
 	if b.ClusterConfig != nil && b.ClusterConfig.VectorAggregatorConfigMapName != "" {
 		vectorFactory := builder.NewVector(
 			TrinoConfigVolumeName,
@@ -223,11 +242,12 @@ func (b *StatefulSetBuilder) getMainContainerEnvVars(ctx context.Context) ([]cor
 		envVars = append(envVars, auth.GetEnvVars()...)
 	}
 
+	envVars = append(envVars, getPodCatalogs(b.ClusterConfig, b.Catalogs).GetEnvVars()...)
+
 	return envVars, nil
 }
 
 func (b *StatefulSetBuilder) getMainContainerArgs(ctx context.Context) ([]string, error) {
-	// TODO: Add s3 tls verification, add s3 truststore to client truststore
 	authCommands := ""
 	if b.ClusterConfig != nil && b.ClusterConfig.Authentication != nil {
 		auth, err := authz.NewAuthentication(ctx, b.Client, b.ClusterConfig.Authentication)
@@ -238,6 +258,8 @@ func (b *StatefulSetBuilder) getMainContainerArgs(ctx context.Context) ([]string
 		authCommands = strings.Join(auth.GetCommands(), "\n")
 	}
 
+	catalogCommands := strings.Join(getPodCatalogs(b.ClusterConfig, b.Catalogs).GetCommands(), "\n")
+
 	arg := `
 set -ex
 mkdir -p ` + TrinoConfigDir + `
@@ -290,14 +312,16 @@ keytool \
 	-importkeystore \
 	-srckeystore /etc/pki/java/cacerts \
 	-srcstoretype JKS \
-	-srcstorepass ` + DefaultTlsPassphrase + `\
-	-destkeystore ` + path.Join(ClientTlsPath, "truststore.p12") + `\
+	-srcstorepass ` + truststore.Passphrase + `\
+	-destkeystore ` + truststore.Path + `\
 	-deststoretype PKCS12 \
-	-deststorepass ` + DefaultTlsPassphrase + `\
+	-deststorepass ` + truststore.Passphrase + `\
 	-noprompt
 
 ` + authCommands + `
 
+` + catalogCommands + `
+
 bin/launcher run --etc-dir ` + TrinoConfigDir + ` --data-dir ` + TrinoDataDir + `
 wait_for_termination $!
 mkdir -p /kubedoop/log/_vector && touch /kubedoop/log/_vector/shutdown
@@ -323,6 +347,10 @@ func (b *StatefulSetBuilder) getMainContainerVolumeMounts(ctx context.Context) (
 			Name:      TrinoClientTlsVolumeName,
 			MountPath: ClientTlsPath,
 		},
+		{
+			Name:      TrinoConfigSecretVolumeName,
+			MountPath: ConfigSecretMountDir,
+		},
 	}
 
 	if b.enabledTls() {
@@ -345,6 +373,15 @@ func (b *StatefulSetBuilder) getMainContainerVolumeMounts(ctx context.Context) (
 		volumes = append(volumes, auth.GetVolumeMounts()...)
 	}
 
+	if claimName := getDynamicCatalogStoreClaimName(b.ClusterConfig); claimName != "" {
+		volumes = append(volumes, corev1.VolumeMount{
+			Name:      TrinoCatalogStoreVolumeName,
+			MountPath: DynamicCatalogStoreDir,
+		})
+	}
+
+	volumes = append(volumes, getPodCatalogs(b.ClusterConfig, b.Catalogs).GetVolumeMounts()...)
+
 	return volumes, nil
 }
 
@@ -376,6 +413,14 @@ func (b *StatefulSetBuilder) getVolumes(ctx context.Context) ([]corev1.Volume, e
 				},
 			},
 		},
+		{
+			Name: TrinoConfigSecretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: b.GetName(),
+				},
+			},
+		},
 	}
 
 	if b.enabledTls() {
@@ -394,6 +439,19 @@ func (b *StatefulSetBuilder) getVolumes(ctx context.Context) ([]corev1.Volume, e
 		volumes = append(volumes, auth.GetVolumes()...)
 	}
 
+	if claimName := getDynamicCatalogStoreClaimName(b.ClusterConfig); claimName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: TrinoCatalogStoreVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+	}
+
+	volumes = append(volumes, getPodCatalogs(b.ClusterConfig, b.Catalogs).GetVolumes()...)
+
 	return volumes, nil
 }
 
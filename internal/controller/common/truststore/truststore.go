@@ -0,0 +1,78 @@
+package truststore
+
+import (
+	"path"
+
+	commonsv1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/commons/v1alpha1"
+	"github.com/zncdatadev/operator-go/pkg/builder"
+	"github.com/zncdatadev/operator-go/pkg/constants"
+	"github.com/zncdatadev/operator-go/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Passphrase of the client truststore.
+	Passphrase = "changeit"
+)
+
+var (
+	// Dir is the directory of the client truststore, the JVM default truststore of trino.
+	Dir = path.Join(constants.KubedoopTlsDir, "client")
+	// Path is the client truststore, it is created from the system CAs when the container starts.
+	Path = path.Join(Dir, "truststore.p12")
+)
+
+// GetCaSecretClass returns the SecretClass providing the server CA of the TLS verification.
+// It returns an empty string when the server is not verified, or verified with the system CAs (webPki).
+func GetCaSecretClass(verification *commonsv1alpha1.TLSVerificationSpec) string {
+	if verification == nil || verification.Server == nil || verification.Server.CACert == nil {
+		return ""
+	}
+	return verification.Server.CACert.SecretClass
+}
+
+// CaCert is a CA certificate provided by secret-operator, it is imported to the client truststore
+// when the container starts.
+type CaCert struct {
+	// Name is used as volume name and truststore alias, it must be unique in the pod.
+	Name        string
+	SecretClass string
+	MountPath   string
+}
+
+func NewCaCert(name string, secretClass string, mountPath string) *CaCert {
+	return &CaCert{
+		Name:        name,
+		SecretClass: secretClass,
+		MountPath:   mountPath,
+	}
+}
+
+func (c *CaCert) GetVolume() corev1.Volume {
+	volume := builder.NewSecretOperatorVolume(c.Name, c.SecretClass)
+	volume.SetScope(&builder.SecretVolumeScope{Pod: true, Node: true})
+	volume.SetFormatName(constants.TLSPEM)
+	return *volume.Builde()
+}
+
+func (c *CaCert) GetVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      c.Name,
+		MountPath: c.MountPath,
+	}
+}
+
+// GetCommand returns the command importing the CA certificate to the client truststore.
+func (c *CaCert) GetCommand() string {
+	cmd := `
+keytool \
+	-importcert \
+	-noprompt \
+	-alias ` + c.Name + ` \
+	-file ` + path.Join(c.MountPath, "ca.crt") + ` \
+	-keystore ` + Path + ` \
+	-storetype PKCS12 \
+	-storepass ` + Passphrase + `
+`
+	return util.IndentTab4Spaces(cmd)
+}
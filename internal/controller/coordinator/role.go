@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
 	"github.com/zncdatadev/trino-operator/internal/controller/common"
 )
 
@@ -20,6 +21,7 @@ type Reconciler struct {
 	reconciler.BaseRoleReconciler[*trinov1alpha1.CoordinatorsSpec]
 
 	ClusterConfig      *trinov1alpha1.ClusterConfigSpec
+	Catalogs           *catalog.TrinoCatalogs
 	Image              *util.Image
 	CoordiantorSvcFqdn string
 }
@@ -28,6 +30,7 @@ func NewWorkerReconciler(
 	client *client.Client,
 	clusterStopped bool,
 	clusterConfig *trinov1alpha1.ClusterConfigSpec,
+	catalogs *catalog.TrinoCatalogs,
 	roleInfo reconciler.RoleInfo,
 	image *util.Image,
 	coordiantorSvcFqdn string,
@@ -36,6 +39,7 @@ func NewWorkerReconciler(
 	return &Reconciler{
 		BaseRoleReconciler: *reconciler.NewBaseRoleReconciler(client, clusterStopped, roleInfo, spec),
 		ClusterConfig:      clusterConfig,
+		Catalogs:           catalogs,
 		Image:              image,
 		CoordiantorSvcFqdn: coordiantorSvcFqdn,
 	}
@@ -86,12 +90,23 @@ func (r *Reconciler) registerResourceWithRoleGroup(
 		r.Client,
 		r.CoordiantorSvcFqdn,
 		r.ClusterConfig,
+		r.Catalogs,
 		roleGroupConfig,
 		info,
 	)
 
 	reconcilers = append(reconcilers, configMapReconciler)
 
+	configSecretReconciler := common.NewConfigSecretReconciler(
+		r.Client,
+		r.CoordiantorSvcFqdn,
+		r.ClusterConfig,
+		r.Catalogs,
+		roleGroupConfig,
+		info,
+	)
+	reconcilers = append(reconcilers, configSecretReconciler)
+
 	serviceReconciler := reconciler.NewServiceReconciler(
 		r.Client,
 		info.GetFullName(),
@@ -116,6 +131,7 @@ func (r *Reconciler) registerResourceWithRoleGroup(
 	statefulSetReconciler, err := common.NewStatefulSetReconciler(
 		r.Client,
 		r.ClusterConfig,
+		r.Catalogs,
 		info,
 		r.Image,
 		r.ClusterStopped(),
@@ -18,17 +18,40 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	s3v1alpha1 "github.com/zncdatadev/operator-go/pkg/apis/s3/v1alpha1"
 	"github.com/zncdatadev/operator-go/pkg/client"
 	"github.com/zncdatadev/operator-go/pkg/reconciler"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
 	"github.com/zncdatadev/trino-operator/internal/controller/cluster"
+	"github.com/zncdatadev/trino-operator/internal/controller/common"
+)
+
+const (
+	// dynamicCatalogRequeueInterval is the interval to retry applying dynamic catalogs.
+	dynamicCatalogRequeueInterval = 30 * time.Second
+
+	// rolloutRequeueInterval is the interval to check the rollout of the static catalogs.
+	rolloutRequeueInterval = 10 * time.Second
 )
 
 // TrinoReconciler reconciles a TrinoCluster object
@@ -48,6 +71,9 @@ type TrinoReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=authentication.kubedoop.dev,resources=authenticationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=s3.kubedoop.dev,resources=s3connections,verbs=get;list;watch
+// +kubebuilder:rbac:groups=secrets.kubedoop.dev,resources=secretclasses,verbs=get
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -75,20 +101,71 @@ func (r *TrinoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	r.Log.Info("TrinoCluster found", "Name", instance.Name)
 
+	// The steps of the reconcile update the status in memory, it is written once at the end.
+	status := instance.Status.DeepCopy()
+	result, err := r.reconcile(ctx, instance)
+	if !equality.Semantic.DeepEqual(status, &instance.Status) {
+		if updateErr := r.Status().Update(ctx, instance); updateErr != nil {
+			return ctrl.Result{}, errors.Join(err, updateErr)
+		}
+	}
+	return result, err
+}
+
+func (r *TrinoReconciler) reconcile(ctx context.Context, instance *trinov1alpha1.TrinoCluster) (ctrl.Result, error) {
 	resourceClient := &client.Client{Client: r.Client, OwnerReference: instance}
 	gvk := instance.GetObjectKind().GroupVersionKind()
 
+	clusterInfo := reconciler.ClusterInfo{
+		GVK: &metav1.GroupVersionKind{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind,
+		},
+		ClusterName: instance.Name,
+	}
+
+	// The catalogs are resolved once, so all resources of the reconcile render the same catalogs.
+	catalogs, err := r.updateCatalogsCondition(ctx, resourceClient, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.updateMigrationCondition(ctx, resourceClient, instance)
+
+	result, err := r.runCluster(ctx, resourceClient, clusterInfo, instance, catalogs)
+	if instance.Spec.ClusterConfig != nil &&
+		instance.Spec.ClusterConfig.CatalogManagement == trinov1alpha1.CatalogManagementDynamic {
+		if err != nil || !result.IsZero() {
+			return result, err
+		}
+		return r.applyDynamicCatalogs(ctx, resourceClient, clusterInfo, instance, catalogs)
+	}
+
+	// The static catalogs are reported even when the run failed, so a changed catalog is not reported as applied.
+	rolloutResult, rolloutErr := r.updateStaticCatalogs(ctx, &clusterInfo, instance, catalogs)
+	if err != nil || rolloutErr != nil {
+		return ctrl.Result{}, errors.Join(err, rolloutErr)
+	}
+	if !result.IsZero() {
+		return result, nil
+	}
+	return rolloutResult, nil
+}
+
+// runCluster reconciles the resources of the cluster rendering the catalogs.
+func (r *TrinoReconciler) runCluster(
+	ctx context.Context,
+	resourceClient *client.Client,
+	clusterInfo reconciler.ClusterInfo,
+	instance *trinov1alpha1.TrinoCluster,
+	catalogs *catalog.TrinoCatalogs,
+) (ctrl.Result, error) {
 	clusterReconcoler := cluster.NewClusterReconciler(
 		resourceClient,
-		reconciler.ClusterInfo{
-			GVK: &metav1.GroupVersionKind{
-				Group:   gvk.Group,
-				Version: gvk.Version,
-				Kind:    gvk.Kind,
-			},
-			ClusterName: instance.Name,
-		},
+		clusterInfo,
 		&instance.Spec,
+		catalogs,
 	)
 
 	if err := clusterReconcoler.RegisterResources(ctx); err != nil {
@@ -98,8 +175,367 @@ func (r *TrinoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	return clusterReconcoler.Run(ctx)
 }
 
+// updateStaticCatalogs reports the catalogs rendered to the catalog files as applied, once all StatefulSets
+// of the cluster rolled out the pod template with the checksum of the catalog files.
+// The cluster is requeued while the rollout is in progress.
+func (r *TrinoReconciler) updateStaticCatalogs(
+	ctx context.Context,
+	clusterInfo *reconciler.ClusterInfo,
+	instance *trinov1alpha1.TrinoCluster,
+	catalogs *catalog.TrinoCatalogs,
+) (ctrl.Result, error) {
+	checksums, err := common.GetCatalogChecksums(instance.Spec.ClusterConfig, catalogs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	podChecksum, err := common.GetPodCatalogChecksum(instance.Spec.ClusterConfig, catalogs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	message, err := r.getRolloutError(ctx, instance.Namespace, clusterInfo.GetLabels(), podChecksum)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := make([]trinov1alpha1.DynamicCatalogStatus, 0, len(names))
+	for _, name := range names {
+		statuses = append(statuses, trinov1alpha1.DynamicCatalogStatus{
+			Name:       name,
+			Checksum:   checksums[name],
+			Generation: catalogs.GetGeneration(name),
+			Applied:    message == "",
+			Message:    message,
+		})
+	}
+	instance.Status.Catalogs = statuses
+
+	if message != "" {
+		return ctrl.Result{RequeueAfter: rolloutRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// getRolloutError returns why the StatefulSets of the cluster have not rolled out the pod template
+// with the catalog checksum, empty when all pods are updated and ready.
+func (r *TrinoReconciler) getRolloutError(
+	ctx context.Context,
+	namespace string,
+	labels map[string]string,
+	checksum string,
+) (string, error) {
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, ctrlclient.InNamespace(namespace), ctrlclient.MatchingLabels(labels)); err != nil {
+		return "", err
+	}
+
+	for _, sts := range statefulSets.Items {
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+		if sts.Spec.Template.Annotations[catalog.AnnotationConfigChecksum] != checksum {
+			return fmt.Sprintf("statefulset %s is not updated with the catalogs yet", sts.Name), nil
+		}
+		if sts.Status.ObservedGeneration != sts.Generation ||
+			sts.Status.CurrentRevision != sts.Status.UpdateRevision ||
+			sts.Status.UpdatedReplicas != replicas ||
+			sts.Status.ReadyReplicas != replicas {
+			return fmt.Sprintf("statefulset %s is rolling out, %d of %d pods are updated and %d are ready",
+				sts.Name, sts.Status.UpdatedReplicas, replicas, sts.Status.ReadyReplicas), nil
+		}
+	}
+	return "", nil
+}
+
+// applyDynamicCatalogs applies the catalogs to the coordinator and reports the status of each catalog.
+// The cluster is requeued while the coordinator is not reachable or a catalog failed to apply.
+func (r *TrinoReconciler) applyDynamicCatalogs(
+	ctx context.Context,
+	resourceClient *client.Client,
+	clusterInfo reconciler.ClusterInfo,
+	instance *trinov1alpha1.TrinoCluster,
+	catalogs *catalog.TrinoCatalogs,
+) (ctrl.Result, error) {
+	manager := common.NewDynamicCatalogManager(resourceClient, clusterInfo, instance.Spec.ClusterConfig, catalogs)
+	statuses, err := manager.Apply(ctx, instance.Status.Catalogs)
+	if err != nil {
+		r.Log.Info("unable to apply dynamic catalogs, retrying", "TrinoCluster", instance.Name, "error", err.Error())
+		return ctrl.Result{RequeueAfter: dynamicCatalogRequeueInterval}, nil
+	}
+	instance.Status.Catalogs = statuses
+
+	for _, status := range statuses {
+		if !status.Applied {
+			return ctrl.Result{RequeueAfter: dynamicCatalogRequeueInterval}, nil
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// updateCatalogsCondition resolves the catalogs selected by the cluster, copies the objects referenced by
+// catalogs from other namespaces, validates the legacy catalog properties and reports the result
+// in the CatalogsResolved condition. The legacy catalog properties are validated against the product version
+// of the cluster, and their warnings and errors are only reported in the message, they are rendered as is,
+// as existing clusters keep working. The resolve error is returned, so the cluster is requeued.
+func (r *TrinoReconciler) updateCatalogsCondition(
+	ctx context.Context,
+	resourceClient *client.Client,
+	instance *trinov1alpha1.TrinoCluster,
+) (*catalog.TrinoCatalogs, error) {
+	condition := metav1.Condition{
+		Type:               trinov1alpha1.ConditionTypeCatalogsResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.ConditionReasonCatalogsResolved,
+		Message:            "All selected catalogs are resolved",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	var warnings []string
+	catalogs, resolveErr := catalog.NewCatalogs(ctx, resourceClient, instance.Spec.ClusterConfig)
+	if resolveErr == nil {
+		resolveErr = catalog.SyncReferences(ctx, resourceClient, catalogs.GetReferences())
+	}
+	var validateErr error
+	if resolveErr == nil && instance.Spec.ClusterConfig != nil {
+		productVersion := trinov1alpha1.DefaultProductVersion
+		if instance.Spec.Image != nil && instance.Spec.Image.ProductVersion != "" {
+			productVersion = instance.Spec.Image.ProductVersion
+		}
+		warnings, validateErr = catalog.ValidateCatalogProperties(productVersion, instance.Spec.ClusterConfig.CatalogProperties)
+	}
+	if resolveErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.ConditionReasonCatalogResolveFailed
+		condition.Message = resolveErr.Error()
+	} else {
+		if len(catalogs.Held) > 0 {
+			condition.Message += ", catalogs kept at their last released spec until their preflight checks pass: " + strings.Join(catalogs.Held, ", ")
+		}
+		if len(warnings) > 0 {
+			condition.Message += ", catalogProperties has warnings: " + strings.Join(warnings, "; ")
+		}
+		if validateErr != nil {
+			condition.Message += ", catalogProperties is invalid and rendered as is: " + strings.ReplaceAll(validateErr.Error(), "\n", "; ")
+		}
+	}
+
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+	return catalogs, resolveErr
+}
+
+// updateMigrationCondition migrates the legacy catalog properties to TrinoCatalogs when enabled,
+// and reports the result in the CatalogPropertiesMigrated condition. A catalog which can not be migrated
+// is only reported, as the legacy catalog keeps working. The TrinoCatalogs created by the migration
+// trigger a new reconcile of the cluster, where the rendered catalogs are verified again.
+func (r *TrinoReconciler) updateMigrationCondition(
+	ctx context.Context,
+	resourceClient *client.Client,
+	instance *trinov1alpha1.TrinoCluster,
+) {
+	if instance.Spec.ClusterConfig == nil || instance.Spec.ClusterConfig.CatalogPropertiesMigration == "" {
+		apimeta.RemoveStatusCondition(&instance.Status.Conditions, trinov1alpha1.ConditionTypeCatalogPropertiesMigrated)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               trinov1alpha1.ConditionTypeCatalogPropertiesMigrated,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.ConditionReasonCatalogsMigrated,
+		Message:            "catalogProperties are migrated to TrinoCatalogs rendering the same catalogs, it can be removed",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	result, err := catalog.MigrateCatalogProperties(ctx, resourceClient, instance)
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.ConditionReasonCatalogMigrationFailed
+		condition.Message = err.Error()
+	case !result.Complete():
+		messages := make([]string, 0, 2)
+		if len(result.Skipped) > 0 {
+			messages = append(messages, "catalogs not migrated: "+strings.Join(result.Skipped, "; "))
+		}
+		if len(result.Mismatched) > 0 {
+			messages = append(messages, "the rendered TrinoCatalogs differ from catalogProperties: "+strings.Join(result.Mismatched, ", "))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.ConditionReasonCatalogsVerifying
+		if len(result.Skipped) > 0 {
+			condition.Reason = trinov1alpha1.ConditionReasonCatalogMigrationIncomplete
+		}
+		condition.Message = strings.Join(messages, ", ")
+	}
+
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+}
+
 func (r *TrinoReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&trinov1alpha1.TrinoCatalog{},
+		catalog.DependencyIndex,
+		catalog.GetDependencies,
+	); err != nil {
+		return err
+	}
+	for _, obj := range []ctrlclient.Object{&corev1.Secret{}, &corev1.ConfigMap{}} {
+		if err := mgr.GetFieldIndexer().IndexField(
+			context.Background(),
+			obj,
+			catalog.ReferenceSourceIndex,
+			catalog.GetReferenceSource,
+		); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&trinov1alpha1.TrinoCluster{}).
+		Watches(
+			&trinov1alpha1.TrinoCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoClustersForCatalog),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoClustersForConfigMap),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoClustersForReferenceSource),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoClustersForNamespace),
+		).
+		Watches(
+			&s3v1alpha1.S3Connection{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoClustersForDependency(catalog.ReferenceKindS3Connection)),
+		).
 		Complete(r)
 }
+
+// findTrinoClustersForConfigMap maps a ConfigMap to the TrinoClusters depending on it, either read when catalogs
+// are resolved, or copied from the namespace of a catalog.
+func (r *TrinoReconciler) findTrinoClustersForConfigMap(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+	requests := r.findTrinoClustersForDependency(catalog.ReferenceKindConfigMap)(ctx, obj)
+	return append(requests, r.findTrinoClustersForReferenceSource(ctx, obj)...)
+}
+
+// findTrinoClustersForReferenceSource maps a ConfigMap or Secret to the TrinoClusters owning a copy of it,
+// so the copies follow their source.
+func (r *TrinoReconciler) findTrinoClustersForReferenceSource(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+	var list ctrlclient.ObjectList
+	switch obj.(type) {
+	case *corev1.Secret:
+		list = &corev1.SecretList{}
+	case *corev1.ConfigMap:
+		list = &corev1.ConfigMapList{}
+	default:
+		return nil
+	}
+	if err := r.List(
+		ctx,
+		list,
+		ctrlclient.MatchingFields{catalog.ReferenceSourceIndex: obj.GetNamespace() + "/" + obj.GetName()},
+	); err != nil {
+		r.Log.Error(err, "unable to list reference copies", "source", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	_ = apimeta.EachListItem(list, func(o runtime.Object) error {
+		copied, ok := o.(ctrlclient.Object)
+		if !ok {
+			return nil
+		}
+		if owner := metav1.GetControllerOf(copied); owner != nil && owner.Kind == "TrinoCluster" {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: copied.GetNamespace(), Name: owner.Name},
+			})
+		}
+		return nil
+	})
+	return requests
+}
+
+// findTrinoClustersForNamespace maps a Namespace to the TrinoClusters selecting catalogs by namespace labels,
+// so a namespace gaining or losing the labels adds or removes its catalogs.
+func (r *TrinoReconciler) findTrinoClustersForNamespace(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+	clusters := &trinov1alpha1.TrinoClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		r.Log.Error(err, "unable to list TrinoClusters")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, cluster := range clusters.Items {
+		config := cluster.Spec.ClusterConfig
+		if config == nil || config.CatalogLabelSelector == nil || config.CatalogLabelSelector.NamespaceSelector == nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+		})
+	}
+	return requests
+}
+
+// findTrinoClustersForDependency maps an object read when catalogs are resolved, e.g. a metastore discovery ConfigMap,
+// to the TrinoClusters selecting the catalogs depending on it.
+func (r *TrinoReconciler) findTrinoClustersForDependency(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+		catalogs := &trinov1alpha1.TrinoCatalogList{}
+		if err := r.List(
+			ctx,
+			catalogs,
+			ctrlclient.InNamespace(obj.GetNamespace()),
+			ctrlclient.MatchingFields{catalog.DependencyIndex: catalog.DependencyKey(kind, obj.GetName())},
+		); err != nil {
+			r.Log.Error(err, "unable to list TrinoCatalogs", kind, obj.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0)
+		for i := range catalogs.Items {
+			requests = append(requests, r.findTrinoClustersForCatalog(ctx, &catalogs.Items[i])...)
+		}
+		return requests
+	}
+}
+
+// findTrinoClustersForCatalog maps a TrinoCatalog to the TrinoClusters selecting it, in any namespace.
+// On update, it is called with both the old and the new object, so clusters losing the catalog are reconciled too.
+func (r *TrinoReconciler) findTrinoClustersForCatalog(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+	trinoCatalog, ok := obj.(*trinov1alpha1.TrinoCatalog)
+	if !ok {
+		return nil
+	}
+
+	clusters := &trinov1alpha1.TrinoClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		r.Log.Error(err, "unable to list TrinoClusters")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, cluster := range clusters.Items {
+		selected, err := catalog.SelectsCatalog(ctx, r.Client, &cluster, trinoCatalog)
+		if err != nil {
+			r.Log.Error(err, "invalid catalog label selector", "TrinoCluster", cluster.Name)
+			continue
+		}
+		if selected {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+			})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,456 @@
+/*
+Copyright 2024 zncdatadev.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/zncdatadev/operator-go/pkg/client"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+const (
+	// catalogStatusRequeueInterval is the interval to refresh the status of a catalog which is not live yet.
+	catalogStatusRequeueInterval = time.Minute
+
+	// preflightInitialInterval and preflightMaxInterval bound the exponential backoff of failing preflight checks.
+	preflightInitialInterval = 10 * time.Second
+	preflightMaxInterval     = 10 * time.Minute
+)
+
+// TrinoCatalogReconciler reports the status of a TrinoCatalog object
+type TrinoCatalogReconciler struct {
+	ctrlclient.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+
+	// EnablePreflight enables the preflight checks of the catalogs, they dial the Services of the cluster
+	// from the operator, so they are only run when the operator allows it.
+	EnablePreflight bool
+
+	preflightBackoff preflightBackoff
+}
+
+// preflightBackoff tracks the failing preflight checks of each catalog generation, so the checks are retried
+// with an exponential backoff, instead of on each reconcile, e.g. on each status change of a consuming cluster.
+type preflightBackoff struct {
+	mu       sync.Mutex
+	attempts map[types.NamespacedName]preflightAttempt
+}
+
+type preflightAttempt struct {
+	generation int64
+	failures   int
+	next       time.Time
+}
+
+// remaining returns the delay until the next check of the catalog generation, zero when it is due.
+func (b *preflightBackoff) remaining(key types.NamespacedName, generation int64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attempt, ok := b.attempts[key]
+	if !ok || attempt.generation != generation {
+		return 0
+	}
+	return max(time.Until(attempt.next), 0)
+}
+
+// failed records a failed check of the catalog generation, and returns the delay until the next check.
+func (b *preflightBackoff) failed(key types.NamespacedName, generation int64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.attempts == nil {
+		b.attempts = make(map[types.NamespacedName]preflightAttempt)
+	}
+	attempt := b.attempts[key]
+	if attempt.generation != generation {
+		attempt = preflightAttempt{generation: generation}
+	}
+	delay := preflightMaxInterval
+	if attempt.failures < 6 {
+		delay = min(preflightInitialInterval<<attempt.failures, preflightMaxInterval)
+	}
+	attempt.failures++
+	attempt.next = time.Now().Add(delay)
+	b.attempts[key] = attempt
+	return delay
+}
+
+// forget drops the failed checks of the catalog.
+func (b *preflightBackoff) forget(key types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.attempts, key)
+}
+
+// +kubebuilder:rbac:groups=trino.kubedoop.dev,resources=trinocatalogs/status,verbs=get;update;patch
+
+// Reconcile resolves the catalog, runs its preflight checks, finds the clusters consuming it
+// and reports whether it is live on them.
+func (r *TrinoCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &trinov1alpha1.TrinoCatalog{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		if ctrlclient.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, "unable to fetch TrinoCatalog")
+			return ctrl.Result{}, err
+		}
+		r.preflightBackoff.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	status := instance.Status.DeepCopy()
+	status.ObservedGeneration = instance.GetGeneration()
+
+	resourceClient := &client.Client{Client: r.Client, OwnerReference: instance}
+	c, resolveErr := catalog.NewCatalog(ctx, resourceClient, instance)
+	resolved := r.getResolvedCondition(instance, resolveErr)
+	apimeta.SetStatusCondition(&status.Conditions, resolved)
+	apimeta.SetStatusCondition(&status.Conditions, r.getValidatedCondition(instance, c, resolveErr))
+
+	preflight, preflightRequeueAfter := r.getPreflightCondition(ctx, instance, c)
+	if preflight != nil {
+		apimeta.SetStatusCondition(&status.Conditions, *preflight)
+	} else {
+		apimeta.RemoveStatusCondition(&status.Conditions, trinov1alpha1.CatalogConditionTypePreflightPassed)
+	}
+
+	clusters, err := r.findConsumingClusters(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.Clusters = make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		status.Clusters = append(status.Clusters, getClusterName(instance, &cluster))
+	}
+
+	// The hold is evaluated on the updated status, so a catalog passing its preflight checks is released at once.
+	// With holdRollout, the released spec is kept in the status, the clusters render it while a later generation
+	// is held.
+	held := instance.DeepCopy()
+	held.Status = *status
+	if resolved.Status == metav1.ConditionTrue && !catalog.IsHeld(held) {
+		status.ReleasedGeneration = instance.GetGeneration()
+		status.ReleasedSpec = nil
+		if instance.Spec.Preflight != nil && instance.Spec.Preflight.HoldRollout {
+			status.ReleasedSpec = instance.Spec.DeepCopy()
+		}
+	}
+	applied := r.getAppliedCondition(held, clusters)
+	apimeta.SetStatusCondition(&status.Conditions, applied)
+
+	errorCondition := metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypeError,
+		Status:             metav1.ConditionFalse,
+		Reason:             trinov1alpha1.CatalogConditionReasonNoError,
+		ObservedGeneration: instance.GetGeneration(),
+	}
+	if resolved.Status != metav1.ConditionTrue {
+		errorCondition.Status = metav1.ConditionTrue
+		errorCondition.Reason = resolved.Reason
+		errorCondition.Message = resolved.Message
+	} else if preflight != nil && preflight.Status == metav1.ConditionFalse {
+		errorCondition.Status = metav1.ConditionTrue
+		errorCondition.Reason = preflight.Reason
+		errorCondition.Message = preflight.Message
+	} else if applied.Status != metav1.ConditionTrue && applied.Reason != trinov1alpha1.CatalogConditionReasonNotConsumed {
+		errorCondition.Status = metav1.ConditionTrue
+		errorCondition.Reason = applied.Reason
+		errorCondition.Message = applied.Message
+	}
+	apimeta.SetStatusCondition(&status.Conditions, errorCondition)
+
+	if !equality.Semantic.DeepEqual(status, &instance.Status) {
+		instance.Status = *status
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if preflightRequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: preflightRequeueAfter}, nil
+	}
+	if errorCondition.Status == metav1.ConditionTrue {
+		return ctrl.Result{RequeueAfter: catalogStatusRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *TrinoCatalogReconciler) getResolvedCondition(instance *trinov1alpha1.TrinoCatalog, resolveErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypeResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.CatalogConditionReasonResolved,
+		Message:            "The catalog is resolved",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+	if resolveErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonResolveFailed
+		condition.Message = resolveErr.Error()
+	}
+	return condition
+}
+
+// getValidatedCondition reports the validation of the catalog properties. Invalid properties make the catalog
+// fail to resolve, a catalog failing to resolve for another reason can not be validated.
+func (r *TrinoCatalogReconciler) getValidatedCondition(
+	instance *trinov1alpha1.TrinoCatalog,
+	c *catalog.Catalog,
+	resolveErr error,
+) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypeValidated,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.CatalogConditionReasonValid,
+		Message:            "The catalog properties are valid",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	var validationErr *catalog.ValidationError
+	switch {
+	case errors.As(resolveErr, &validationErr):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonInvalid
+		condition.Message = validationErr.Error()
+	case resolveErr != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = trinov1alpha1.CatalogConditionReasonNotResolved
+		condition.Message = "The catalog is not resolved"
+	case len(c.Warnings) > 0:
+		condition.Reason = trinov1alpha1.CatalogConditionReasonUnknownProperties
+		condition.Message = strings.Join(c.Warnings, "; ")
+	}
+	return condition
+}
+
+// getPreflightCondition checks the services the resolved catalog connects to, nil when the catalog has no preflight
+// checks. The checks disabled by the operator are reported as unknown, and do not hold the catalog back.
+// A generation is checked until it passes, the checks of a failing generation are retried with an exponential
+// backoff, the returned delay until the next check is zero when the checks passed.
+func (r *TrinoCatalogReconciler) getPreflightCondition(
+	ctx context.Context,
+	instance *trinov1alpha1.TrinoCatalog,
+	c *catalog.Catalog,
+) (*metav1.Condition, time.Duration) {
+	if instance.Spec.Preflight == nil {
+		return nil, 0
+	}
+
+	condition := &metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypePreflightPassed,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.CatalogConditionReasonPreflightPassed,
+		Message:            "The services of the catalog are reachable",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+	if !r.EnablePreflight {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = trinov1alpha1.CatalogConditionReasonPreflightDisabled
+		condition.Message = "Preflight checks are disabled by the operator, it is started without --enable-catalog-preflight"
+		return condition, 0
+	}
+	if c == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = trinov1alpha1.CatalogConditionReasonNotResolved
+		condition.Message = "The catalog is not resolved"
+		return condition, 0
+	}
+
+	key := ctrlclient.ObjectKeyFromObject(instance)
+	existing := apimeta.FindStatusCondition(instance.Status.Conditions, trinov1alpha1.CatalogConditionTypePreflightPassed)
+	if existing != nil && existing.ObservedGeneration == instance.GetGeneration() {
+		if existing.Status == metav1.ConditionTrue {
+			return existing.DeepCopy(), 0
+		}
+		if delay := r.preflightBackoff.remaining(key, instance.GetGeneration()); delay > 0 {
+			return existing.DeepCopy(), delay
+		}
+	}
+
+	skipped, err := catalog.Preflight(ctx, r.Client, instance.Namespace, instance.Spec.Preflight, c.GetConfigProperties())
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonPreflightFailed
+		condition.Message = strings.ReplaceAll(err.Error(), "\n", "; ")
+		return condition, r.preflightBackoff.failed(key, instance.GetGeneration())
+	}
+	if len(skipped) > 0 {
+		condition.Message += ", the addresses which are not Services of the cluster are not checked: " + strings.Join(skipped, ", ")
+	}
+	r.preflightBackoff.forget(key)
+	return condition, 0
+}
+
+// getAppliedCondition reports whether the catalog is live on all consuming clusters.
+// A catalog is live when the cluster reconciled its current generation, and reports the current generation
+// of the catalog as applied, i.e. created on the coordinator with dynamic catalog management,
+// or rolled out to all pods with static catalog management.
+func (r *TrinoCatalogReconciler) getAppliedCondition(
+	instance *trinov1alpha1.TrinoCatalog,
+	clusters []trinov1alpha1.TrinoCluster,
+) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               trinov1alpha1.CatalogConditionTypeApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             trinov1alpha1.CatalogConditionReasonApplied,
+		Message:            "The catalog is applied to all consuming clusters",
+		ObservedGeneration: instance.GetGeneration(),
+	}
+	if len(clusters) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonNotConsumed
+		condition.Message = "No TrinoCluster selects the catalog"
+		return condition
+	}
+
+	if catalog.IsHeld(instance) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonHeld
+		condition.Message = "The catalog is held back until its preflight checks pass"
+		if instance.Status.ReleasedSpec != nil {
+			condition.Message += fmt.Sprintf(", the consuming clusters keep generation %d", instance.Status.ReleasedGeneration)
+		}
+		return condition
+	}
+
+	pending := make([]string, 0)
+	for _, cluster := range clusters {
+		if message := getClusterCatalogError(&cluster, instance.Name, instance.GetGeneration()); message != "" {
+			pending = append(pending, fmt.Sprintf("%s: %s", getClusterName(instance, &cluster), message))
+		}
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = trinov1alpha1.CatalogConditionReasonPending
+		condition.Message = strings.Join(pending, "; ")
+	}
+	return condition
+}
+
+// getClusterCatalogError returns why the generation of the catalog is not live on the cluster, empty if it is live.
+func getClusterCatalogError(cluster *trinov1alpha1.TrinoCluster, catalogName string, generation int64) string {
+	condition := apimeta.FindStatusCondition(cluster.Status.Conditions, trinov1alpha1.ConditionTypeCatalogsResolved)
+	if condition == nil || condition.ObservedGeneration != cluster.GetGeneration() {
+		return "the catalogs of the cluster are not resolved yet"
+	}
+	if condition.Status != metav1.ConditionTrue {
+		return condition.Message
+	}
+
+	if cluster.Spec.ClusterConfig != nil {
+		if override, ok := cluster.Spec.ClusterConfig.CatalogOverrides[catalogName]; ok && override.Alias != "" {
+			catalogName = override.Alias
+		}
+	}
+	for _, status := range cluster.Status.Catalogs {
+		if status.Name != catalogName {
+			continue
+		}
+		switch {
+		case status.Generation != generation:
+			return fmt.Sprintf("generation %d of the catalog is not applied yet", generation)
+		case !status.Applied && status.Message != "":
+			return status.Message
+		case !status.Applied:
+			return "the catalog is not applied yet"
+		}
+		return ""
+	}
+	return "the catalog is not applied yet"
+}
+
+// findConsumingClusters returns the TrinoClusters selecting the catalog, in any namespace.
+func (r *TrinoCatalogReconciler) findConsumingClusters(
+	ctx context.Context,
+	instance *trinov1alpha1.TrinoCatalog,
+) ([]trinov1alpha1.TrinoCluster, error) {
+	clusters := &trinov1alpha1.TrinoClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return nil, err
+	}
+
+	result := make([]trinov1alpha1.TrinoCluster, 0)
+	for _, cluster := range clusters.Items {
+		selected, err := catalog.SelectsCatalog(ctx, r.Client, &cluster, instance)
+		if err != nil {
+			r.Log.Error(err, "invalid catalog label selector", "TrinoCluster", cluster.Name)
+			continue
+		}
+		if selected {
+			result = append(result, cluster)
+		}
+	}
+	return result, nil
+}
+
+// getClusterName returns the name of a consuming cluster, prefixed by its namespace when it is from another namespace.
+func getClusterName(instance *trinov1alpha1.TrinoCatalog, cluster *trinov1alpha1.TrinoCluster) string {
+	if cluster.Namespace == instance.Namespace {
+		return cluster.Name
+	}
+	return cluster.Namespace + "/" + cluster.Name
+}
+
+func (r *TrinoCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&trinov1alpha1.TrinoCatalog{}).
+		Watches(
+			&trinov1alpha1.TrinoCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.findTrinoCatalogsForCluster),
+		).
+		Complete(r)
+}
+
+// findTrinoCatalogsForCluster maps a TrinoCluster to the TrinoCatalogs selected by it, so the status of
+// the catalogs follows the cluster. On update, it is called with both the old and the new object.
+func (r *TrinoCatalogReconciler) findTrinoCatalogsForCluster(ctx context.Context, obj ctrlclient.Object) []reconcile.Request {
+	cluster, ok := obj.(*trinov1alpha1.TrinoCluster)
+	if !ok || cluster.Spec.ClusterConfig == nil {
+		return nil
+	}
+
+	catalogs, err := catalog.ListTrinoCatalogs(ctx, r.Client, cluster.Namespace, cluster.Spec.ClusterConfig.CatalogLabelSelector)
+	if err != nil {
+		r.Log.Error(err, "unable to list TrinoCatalogs", "TrinoCluster", cluster.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(catalogs))
+	for _, c := range catalogs {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: c.Namespace, Name: c.Name},
+		})
+	}
+	return requests
+}
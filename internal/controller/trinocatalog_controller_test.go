@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	trinov1alpha1 "github.com/zncdatadev/trino-operator/api/v1alpha1"
+	"github.com/zncdatadev/trino-operator/internal/controller/catalog"
+)
+
+func newResolvedCluster(generation int64, catalogs ...trinov1alpha1.DynamicCatalogStatus) *trinov1alpha1.TrinoCluster {
+	return &trinov1alpha1.TrinoCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino", Generation: generation},
+		Spec: trinov1alpha1.TrinoClusterSpec{
+			ClusterConfig: &trinov1alpha1.ClusterConfigSpec{
+				CatalogOverrides: map[string]trinov1alpha1.CatalogOverrideSpec{"tpch": {Alias: "bench"}},
+			},
+		},
+		Status: trinov1alpha1.TrinoClusterStatus{
+			Catalogs: catalogs,
+		},
+	}
+}
+
+func TestGetClusterCatalogError(t *testing.T) {
+	resolved := func(cluster *trinov1alpha1.TrinoCluster, observedGeneration int64) *trinov1alpha1.TrinoCluster {
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type:               trinov1alpha1.ConditionTypeCatalogsResolved,
+			Status:             metav1.ConditionTrue,
+			Reason:             trinov1alpha1.ConditionReasonCatalogsResolved,
+			ObservedGeneration: observedGeneration,
+		}}
+		return cluster
+	}
+
+	tests := []struct {
+		name    string
+		cluster *trinov1alpha1.TrinoCluster
+		want    string
+	}{
+		{
+			name: "applied",
+			cluster: resolved(newResolvedCluster(3,
+				trinov1alpha1.DynamicCatalogStatus{Name: "bench", Generation: 2, Applied: true},
+			), 3),
+		},
+		{
+			name: "cluster not reconciled",
+			cluster: resolved(newResolvedCluster(3,
+				trinov1alpha1.DynamicCatalogStatus{Name: "bench", Generation: 2, Applied: true},
+			), 2),
+			want: "the catalogs of the cluster are not resolved yet",
+		},
+		{
+			name: "previous generation applied",
+			cluster: resolved(newResolvedCluster(3,
+				trinov1alpha1.DynamicCatalogStatus{Name: "bench", Generation: 1, Applied: true},
+			), 3),
+			want: "generation 2 of the catalog is not applied yet",
+		},
+		{
+			name: "rolling out",
+			cluster: resolved(newResolvedCluster(3,
+				trinov1alpha1.DynamicCatalogStatus{Name: "bench", Generation: 2, Message: "statefulset trino-worker-default is rolling out"},
+			), 3),
+			want: "statefulset trino-worker-default is rolling out",
+		},
+		{
+			name:    "not rendered",
+			cluster: resolved(newResolvedCluster(3), 3),
+			want:    "the catalog is not applied yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(getClusterCatalogError(tt.cluster, "tpch", 2)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetRolloutError(t *testing.T) {
+	labels := map[string]string{"app.kubernetes.io/instance": "trino"}
+	newStatefulSet := func(checksum string, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "trino", Name: "trino-worker-default", Labels: labels, Generation: 2},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](2),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{catalog.AnnotationConfigChecksum: checksum},
+					},
+				},
+			},
+			Status: status,
+		}
+	}
+	rolledOut := appsv1.StatefulSetStatus{
+		ObservedGeneration: 2,
+		Replicas:           2,
+		ReadyReplicas:      2,
+		UpdatedReplicas:    2,
+		CurrentRevision:    "trino-worker-default-2",
+		UpdateRevision:     "trino-worker-default-2",
+	}
+	rollingOut := rolledOut
+	rollingOut.UpdatedReplicas = 1
+	rollingOut.CurrentRevision = "trino-worker-default-1"
+
+	tests := []struct {
+		name        string
+		statefulSet *appsv1.StatefulSet
+		want        string
+	}{
+		{
+			name:        "rolled out",
+			statefulSet: newStatefulSet("new", rolledOut),
+		},
+		{
+			name:        "not updated",
+			statefulSet: newStatefulSet("old", rolledOut),
+			want:        "statefulset trino-worker-default is not updated with the catalogs yet",
+		},
+		{
+			name:        "rolling out",
+			statefulSet: newStatefulSet("new", rollingOut),
+			want:        "statefulset trino-worker-default is rolling out, 1 of 2 pods are updated and 2 are ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			r := &TrinoReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.statefulSet).Build(),
+				Scheme: scheme,
+			}
+
+			message, err := r.getRolloutError(context.Background(), "trino", labels, "new")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(message).To(Equal(tt.want))
+		})
+	}
+}